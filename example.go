@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"github.com/Troublor/jasmine-eth-go/sdk"
 	"math/big"
+	"strings"
 )
 
 func checkErr(err error) {
@@ -14,9 +15,6 @@ func checkErr(err error) {
 	}
 }
 
-func depositTxHashUsed(txHash string) bool {
-	return true
-}
 func main() {
 	const transactionFeeRate = 0.1
 
@@ -40,38 +38,41 @@ func main() {
 	amount := new(big.Int)
 	amount.SetString("1000000000000000000", 10)
 
-	requiredTransferAmount, estimatedGas, gasPrice, err := tfcContract.EstimateTFCExchangeFee(context.Background(), recipient, amount, bridgeAccount, 0, transactionFeeRate)
+	quote, err := tfcContract.EstimateTFCExchangeFee(context.Background(), recipient, amount, bridgeAccount, 0, transactionFeeRate)
 	if err != nil {
 		checkErr(err)
 	}
-	fmt.Println("required transfer amount", requiredTransferAmount.Uint64())
-	fmt.Println("estimated gas", estimatedGas)
-	fmt.Println("gas price", gasPrice.Uint64())
+	fmt.Println("quote", quote.ID)
+	fmt.Println("required transfer amount", quote.RequiredTransferAmount.Uint64())
+	fmt.Println("estimated gas", quote.EstimatedGas)
+	fmt.Println("gas price", quote.GasPrice.Uint64())
 
-	depositTransactionHash := "0x0e87e93aa08fd149f4f66e6939543b220b2ac77697f786c0ca5e4e88022c564d"
-	if depositTxHashUsed(depositTransactionHash) {
-		panic(errors.New("deposit tx used"))
+	ledger, err := sdk.OpenLevelDBDepositLedger("deposits")
+	if err != nil {
+		checkErr(err)
 	}
+	defer ledger.Close()
+	// deposits claimed by SendMintTransaction are persisted, so that they are never minted twice
+	sdkObject.SetDepositLedger(ledger)
+
+	depositTransactionHash := "0x0e87e93aa08fd149f4f66e6939543b220b2ac77697f786c0ca5e4e88022c564d"
 	recipient, depositAmount, err := tfcContract.CheckTransactionFeeDeposit(context.Background(), depositTransactionHash, bridgeAccount.Address(), 6)
 	if err != nil {
 		checkErr(err)
 	}
 
-	if depositAmount.Cmp(requiredTransferAmount) < 0 {
-		panic(errors.New("deposit amount too small"))
+	if !strings.EqualFold(string(recipient), string(quote.Recipient)) {
+		panic(errors.New("deposit is not sent by the quoted recipient"))
 	}
 
 	fmt.Println("recipient", recipient)
 	fmt.Println("deposit amount", depositAmount.Uint64())
 	txHash, err := tfcContract.SendMintTransaction(
 		context.Background(),
-		recipient,
-		amount,
+		quote,
+		depositTransactionHash,
 		bridgeAccount,
 		depositAmount,
-		estimatedGas,
-		gasPrice,
-		transactionFeeRate,
 	)
 	if err != nil {
 		checkErr(err)
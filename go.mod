@@ -3,7 +3,11 @@ module github.com/Troublor/jasmine-eth-go
 go 1.14
 
 require (
-	github.com/ethereum/go-ethereum v1.9.21
+	github.com/ethereum/go-ethereum v1.10.26
+	github.com/google/uuid v1.2.0
 	github.com/offchainlabs/go-solidity-sha3 v0.1.2
 	github.com/status-im/keycard-go v0.0.0-20190316090335-8537d3370df4
+	github.com/syndtr/goleveldb v1.0.1-0.20210819022825-2ae1ddf74ef7
+	github.com/tyler-smith/go-bip39 v1.1.0
+	golang.org/x/crypto v0.0.0-20210921155107-089bfa567519
 )
@@ -0,0 +1,173 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"github.com/Troublor/jasmine-eth-go/token"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"strings"
+)
+
+// Payout is a single transfer of TFC in a batch transfer
+type Payout struct {
+	To     Address
+	Amount *big.Int
+}
+
+// BatchTransferResult is the outcome of one chunk of a batch transfer, which is sent as one one2manyTransfer transaction
+type BatchTransferResult struct {
+	Payouts         []Payout
+	TransactionHash Hash
+	Transaction     *PendingTx
+	Nonce           uint64
+	GasLimit        uint64
+	Err             error // nil if the chunk transaction has been confirmed, *RevertedError if it is reverted
+}
+
+/**
+Transfer TFC from sender to many recipients using one2manyTransfer of TFC ERC20 contract.
+
+The payouts are split into chunks, each of which fits in the block gas limit, and the chunks are sent in nonce order.
+It blocks until all chunk transactions are confirmed (or failed) and returns one result for each chunk.
+The returned err is non-nil only if the payouts are invalid or the chunks cannot be planned,
+in which case no transaction is sent.
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) BatchTransfer(ctx context.Context, payouts []Payout, sender *Account) (results []BatchTransferResult, err error) {
+	// validate payouts
+	total := big.NewInt(0)
+	for _, payout := range payouts {
+		if !payout.To.IsValid() {
+			return nil, InvalidAddressError
+		}
+		if payout.Amount == nil || payout.Amount.Sign() < 0 {
+			return nil, InvalidAmountErr
+		}
+		total.Add(total, payout.Amount)
+	}
+	if len(payouts) == 0 {
+		return results, nil
+	}
+	balance, err := tfc.BalanceOf(sender.Address())
+	if err != nil {
+		return nil, err
+	}
+	if balance.Cmp(total) < 0 {
+		return nil, InsufficientBalanceErr
+	}
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
+	}
+
+	// split payouts into chunks
+	header, err := tfc.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	for remaining := payouts; len(remaining) > 0; {
+		size, gas, err := tfc.batchChunkSize(ctx, remaining, sender, header.GasLimit)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, BatchTransferResult{Payouts: remaining[:size], GasLimit: gas})
+		remaining = remaining[size:]
+	}
+
+	// send chunks in nonce order
+	fees, err := tfc.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var sendErr error
+	for i := range results {
+		if sendErr != nil {
+			// later nonces cannot be mined if a previous chunk is not sent
+			results[i].Err = SkippedBatchChunkErr
+			continue
+		}
+		tos, amounts := splitPayouts(results[i].Payouts)
+		gasLimit := results[i].GasLimit
+		pending, err := tfc.transactWithFees(ctx, sender, fees, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+			auth.GasLimit = gasLimit
+			return tfc.contract.One2manyTransfer(auth, tos, amounts)
+		})
+		if err != nil {
+			results[i].Err = err
+			sendErr = err
+			continue
+		}
+		results[i].Nonce = pending.Nonce()
+		results[i].Transaction = pending
+		results[i].TransactionHash = pending.Hash()
+	}
+
+	// wait for confirmations
+	for i := range results {
+		if results[i].Err != nil {
+			continue
+		}
+		_, results[i].Err = results[i].Transaction.Wait(ctx, ConfirmationRequirement)
+		if results[i].Err == nil {
+			results[i].Err = results[i].Transaction.RevertError(ctx)
+		}
+	}
+	return results, nil
+}
+
+/**
+Returns the number of leading payouts that can be sent in one transaction without exceeding gasLimit,
+together with the estimated gas of the transaction.
+*/
+func (tfc *TFC) batchChunkSize(ctx context.Context, payouts []Payout, sender *Account, gasLimit uint64) (size int, gas uint64, err error) {
+	parsedABI, err := abi.JSON(strings.NewReader(token.TFCTokenABI))
+	if err != nil {
+		return 0, 0, err
+	}
+	tfcAddress := tfc.address.address()
+	for size = len(payouts); ; {
+		tos, amounts := splitPayouts(payouts[:size])
+		input, err := parsedABI.Pack("one2manyTransfer", tos, amounts)
+		if err != nil {
+			return 0, 0, err
+		}
+		msg := ethereum.CallMsg{From: sender.address, To: &tfcAddress, Value: big.NewInt(0), Data: input}
+		gas, err = tfc.backend.EstimateGas(ctx, msg)
+		if err == nil && gas <= gasLimit {
+			return size, gas, nil
+		}
+		if err != nil && !strings.Contains(err.Error(), "gas required exceeds allowance") {
+			return 0, 0, fmt.Errorf("failed to estimate gas needed: %v", err)
+		}
+		if size == 1 {
+			return 0, 0, InsufficientGasErr
+		}
+		// shrink the chunk proportionally if the estimation is known, otherwise halve it
+		next := size / 2
+		if err == nil {
+			next = int(uint64(size) * gasLimit / gas)
+		}
+		if next >= size {
+			next = size - 1
+		}
+		if next < 1 {
+			next = 1
+		}
+		size = next
+	}
+}
+
+func splitPayouts(payouts []Payout) (tos []common.Address, amounts []*big.Int) {
+	tos = make([]common.Address, len(payouts))
+	amounts = make([]*big.Int, len(payouts))
+	for i, payout := range payouts {
+		tos[i] = payout.To.address()
+		amounts[i] = payout.Amount
+	}
+	return tos, amounts
+}
@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestTFC_BatchTransfer(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	admin := PredefinedAccounts[0]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), admin)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	payouts := make([]Payout, 400)
+	total := big.NewInt(0)
+	for i := range payouts {
+		payouts[i] = Payout{To: createAccount().Address(), Amount: big.NewInt(int64(i + 1))}
+		total.Add(total, payouts[i].Amount)
+	}
+
+	// not enough balance
+	_, err = tfc.BatchTransfer(context.Background(), payouts, admin)
+	if err != InsufficientBalanceErr {
+		t.Fatal("batch transfer should check balance", err)
+	}
+
+	err = tfc.MintSync(context.Background(), admin.Address(), total, admin)
+	checkError(t, err)
+
+	results, err := tfc.BatchTransfer(context.Background(), payouts, admin)
+	checkError(t, err)
+	if len(results) < 2 {
+		t.Fatal("payouts should be split into multiple chunks, got", len(results))
+	}
+	count := 0
+	for i, result := range results {
+		checkError(t, result.Err)
+		if result.GasLimit > 4712388 {
+			t.Fatal("chunk exceeds block gas limit")
+		}
+		if i > 0 && result.Nonce != results[i-1].Nonce+1 {
+			t.Fatal("chunks are not sent in nonce order")
+		}
+		count += len(result.Payouts)
+	}
+	if count != len(payouts) {
+		t.Fatal("not all payouts are sent")
+	}
+
+	for _, payout := range []Payout{payouts[0], payouts[len(payouts)/2], payouts[len(payouts)-1]} {
+		balance, err := tfc.BalanceOf(payout.To)
+		checkError(t, err)
+		if balance.Cmp(payout.Amount) != 0 {
+			t.Fatal("batch transfer does not work")
+		}
+	}
+	balance, err := tfc.BalanceOf(admin.Address())
+	checkError(t, err)
+	if balance.Sign() != 0 {
+		t.Fatal("sender balance is not deducted")
+	}
+
+	// invalid address
+	_, err = tfc.BatchTransfer(context.Background(), []Payout{{To: "0x123", Amount: big.NewInt(1)}}, admin)
+	if err != InvalidAddressError {
+		t.Fatal("invalid address should be rejected")
+	}
+}
@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+/**
+ClaimAuditAction is what ClaimIssuer did to a voucher.
+*/
+type ClaimAuditAction string
+
+const (
+	ClaimIssued   ClaimAuditAction = "issued"
+	ClaimRevoking ClaimAuditAction = "revoking" // the self-claim revoking the voucher is sent but not confirmed
+	ClaimRevoked  ClaimAuditAction = "revoked"  // the self-claim revoking the voucher is confirmed
+)
+
+/**
+ClaimAuditEntry records a voucher issued, being revoked or revoked by ClaimIssuer.
+TxHash is the hash of the self-claim transaction which revokes the voucher.
+*/
+type ClaimAuditEntry struct {
+	Time    time.Time        `json:"time"`
+	Action  ClaimAuditAction `json:"action"`
+	Voucher ClaimVoucher     `json:"voucher"`
+	TxHash  Hash             `json:"txHash,omitempty"`
+}
+
+/**
+ClaimAuditLog is the append-only log of ClaimIssuer, from which its state is restored.
+*/
+type ClaimAuditLog interface {
+	// Append appends the entry, which must be durable when it returns
+	Append(entry ClaimAuditEntry) error
+	// Load returns all appended entries in order
+	Load() ([]ClaimAuditEntry, error)
+}
+
+/**
+MemoryClaimAuditLog keeps the entries in memory, which are lost when the process exits.
+*/
+type MemoryClaimAuditLog struct {
+	lock    sync.Mutex
+	entries []ClaimAuditEntry
+}
+
+func NewMemoryClaimAuditLog() *MemoryClaimAuditLog {
+	return &MemoryClaimAuditLog{}
+}
+
+func (l *MemoryClaimAuditLog) Append(entry ClaimAuditEntry) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.entries = append(l.entries, entry)
+	return nil
+}
+
+func (l *MemoryClaimAuditLog) Load() ([]ClaimAuditEntry, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]ClaimAuditEntry{}, l.entries...), nil
+}
+
+/**
+FileClaimAuditLog appends the entries as JSON lines to a file, and every append is synced before it returns.
+*/
+type FileClaimAuditLog struct {
+	lock sync.Mutex
+	path string
+	file *os.File
+}
+
+/**
+Open (or create) the FileClaimAuditLog at path, which is only appended to.
+*/
+func OpenFileClaimAuditLog(path string) (log *FileClaimAuditLog, err error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileClaimAuditLog{path: path, file: file}, nil
+}
+
+func (l *FileClaimAuditLog) Close() error {
+	return l.file.Close()
+}
+
+func (l *FileClaimAuditLog) Append(entry ClaimAuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+func (l *FileClaimAuditLog) Load() ([]ClaimAuditEntry, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	file, err := os.Open(l.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var entries []ClaimAuditEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry ClaimAuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,303 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sync"
+	"time"
+)
+
+/**
+ClaimIssuanceLimits caps the amount of TFC issued by ClaimIssuer per UTC day. A nil limit means unlimited.
+*/
+type ClaimIssuanceLimits struct {
+	PerRecipient *big.Int // amount issued to each recipient
+	Total        *big.Int // amount issued to all recipients
+}
+
+/**
+ClaimRequest asks ClaimIssuer to issue a voucher of amount TFC to the recipient.
+*/
+type ClaimRequest struct {
+	Recipient Address
+	Amount    *big.Int
+}
+
+/**
+ClaimIssuer issues vouchers of TFC claims (see IssueClaimVoucher) in batches, with the signer of TFC Manager contract.
+Every voucher issued or revoked is appended to the ClaimAuditLog before it is returned,
+and the issuer restores its state from the log, so that the daily limits hold across restarts.
+*/
+type ClaimIssuer struct {
+	manager *Manager
+	signer  *Account
+	log     ClaimAuditLog
+	limits  ClaimIssuanceLimits
+
+	lock     sync.Mutex
+	day      string                      // the UTC day of the issuance below
+	issued   *big.Int                    // amount issued in the day
+	issuedTo map[common.Address]*big.Int // amount issued to each recipient in the day
+	vouchers map[string]*ClaimVoucher    // nonce => voucher issued
+	revoking map[string][]Hash           // nonce => self-claim transactions sent to revoke the voucher
+	revoked  map[string]bool             // nonces of revoked vouchers
+}
+
+/**
+Create a ClaimIssuer of the manager signing with signer, which restores the issued vouchers from the audit log.
+Entries of other Manager contracts in the log are ignored.
+The nonces of the restored vouchers which are neither revoked nor redeemed are reserved again in the NonceAllocator of the manager,
+so that they are not handed out again even if the allocator does not persist its reservations.
+The redeemed vouchers are known from the ClaimTFC events queried by the allocator, which are only queried for the blocks not queried before.
+Expired vouchers are included, since their expiry is not enforced by the contract.
+*/
+func (manager *Manager) NewClaimIssuer(signer *Account, log ClaimAuditLog, limits ClaimIssuanceLimits) (issuer *ClaimIssuer, err error) {
+	entries, err := log.Load()
+	if err != nil {
+		return nil, err
+	}
+	issuer = &ClaimIssuer{
+		manager:  manager,
+		signer:   signer,
+		log:      log,
+		limits:   limits,
+		issued:   big.NewInt(0),
+		issuedTo: make(map[common.Address]*big.Int),
+		vouchers: make(map[string]*ClaimVoucher),
+		revoking: make(map[string][]Hash),
+		revoked:  make(map[string]bool),
+	}
+	for _, entry := range entries {
+		if entry.Voucher.Manager.address() == manager.address && entry.Voucher.Nonce != nil {
+			issuer.apply(entry)
+		}
+	}
+	var outstanding []*big.Int
+	for key, voucher := range issuer.vouchers {
+		if !issuer.revoked[key] {
+			outstanding = append(outstanding, voucher.Nonce)
+		}
+	}
+	if err := manager.NonceAllocator().reserveIssued(context.Background(), outstanding); err != nil {
+		return nil, err
+	}
+	return issuer, nil
+}
+
+// utcDay returns the UTC day of the time, e.g. 2006-01-02
+func utcDay(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// rollover resets the daily issuance if the time is in another day
+func (issuer *ClaimIssuer) rollover(now time.Time) {
+	if day := utcDay(now); day != issuer.day {
+		issuer.day = day
+		issuer.issued = big.NewInt(0)
+		issuer.issuedTo = make(map[common.Address]*big.Int)
+	}
+}
+
+// apply updates the state with the audit log entry
+func (issuer *ClaimIssuer) apply(entry ClaimAuditEntry) {
+	voucher := entry.Voucher
+	switch entry.Action {
+	case ClaimIssued:
+		issuer.vouchers[voucher.Nonce.String()] = &voucher
+		issuer.rollover(entry.Time)
+		recipient := voucher.Recipient.address()
+		issuer.issued = new(big.Int).Add(issuer.issued, voucher.Amount)
+		issuer.issuedTo[recipient] = new(big.Int).Add(issuer.issuedToday(recipient), voucher.Amount)
+	case ClaimRevoking:
+		issuer.revoking[voucher.Nonce.String()] = append(issuer.revoking[voucher.Nonce.String()], entry.TxHash)
+	case ClaimRevoked:
+		issuer.revoked[voucher.Nonce.String()] = true
+	}
+}
+
+// issuedToday returns the amount issued to the recipient in the day
+func (issuer *ClaimIssuer) issuedToday(recipient common.Address) *big.Int {
+	if amount, ok := issuer.issuedTo[recipient]; ok {
+		return amount
+	}
+	return big.NewInt(0)
+}
+
+// checkLimits checks that the requests do not exceed the limits on top of the issuance of the day
+func (issuer *ClaimIssuer) checkLimits(requests []ClaimRequest) error {
+	total := new(big.Int).Set(issuer.issued)
+	perRecipient := make(map[common.Address]*big.Int)
+	for _, request := range requests {
+		recipient := request.Recipient.address()
+		if _, ok := perRecipient[recipient]; !ok {
+			perRecipient[recipient] = new(big.Int).Set(issuer.issuedToday(recipient))
+		}
+		perRecipient[recipient].Add(perRecipient[recipient], request.Amount)
+		total.Add(total, request.Amount)
+		if issuer.limits.PerRecipient != nil && perRecipient[recipient].Cmp(issuer.limits.PerRecipient) > 0 {
+			return ClaimLimitExceededErr
+		}
+	}
+	if issuer.limits.Total != nil && total.Cmp(issuer.limits.Total) > 0 {
+		return ClaimLimitExceededErr
+	}
+	return nil
+}
+
+/**
+Issue a voucher for each request, whose nonce is reserved by the NonceAllocator of the manager.
+ClaimLimitExceededErr is returned and nothing is issued if the requests exceed the daily limits as a whole.
+If issuing fails midway, the vouchers issued (and logged) before the failure are returned along with the error.
+*/
+func (issuer *ClaimIssuer) Issue(ctx context.Context, requests []ClaimRequest) (vouchers []*ClaimVoucher, err error) {
+	for _, request := range requests {
+		if !request.Recipient.IsValid() {
+			return nil, InvalidAddressError
+		}
+		if request.Amount == nil || request.Amount.Sign() < 0 {
+			return nil, InvalidAmountErr
+		}
+	}
+	issuer.lock.Lock()
+	defer issuer.lock.Unlock()
+	issuer.rollover(time.Now())
+	if err := issuer.checkLimits(requests); err != nil {
+		return nil, err
+	}
+	for _, request := range requests {
+		voucher, err := issuer.manager.IssueClaimVoucher(ctx, request.Recipient, request.Amount, issuer.signer)
+		if err != nil {
+			return vouchers, err
+		}
+		entry := ClaimAuditEntry{Time: time.Now(), Action: ClaimIssued, Voucher: *voucher}
+		if err := issuer.log.Append(entry); err != nil {
+			// the voucher is never handed out, so that its nonce can be reused
+			_ = issuer.manager.NonceAllocator().Release(voucher.Nonce)
+			return vouchers, err
+		}
+		issuer.apply(entry)
+		vouchers = append(vouchers, voucher)
+	}
+	return vouchers, nil
+}
+
+/**
+Revoke the unused voucher issued with the nonce, by spending the nonce through a claim of zero TFC to the signer itself,
+which is sent by the signer. The self-claim is logged as revoking once it is sent,
+and the revocation is logged only once the self-claim is confirmed successfully, by Revoke or RevokeSync,
+or by a later RevokeTx which finds the nonce used by a logged self-claim (VoucherRevokedErr is returned then).
+RevokeTx can be retried while the nonce is unused, e.g. if the self-claim is dropped.
+VoucherNotIssuedErr is returned if the voucher is not issued by this issuer, VoucherRevokedErr if it has been revoked,
+and NonceUsedErr if it has been redeemed.
+*/
+func (issuer *ClaimIssuer) RevokeTx(ctx context.Context, nonce *big.Int) (pending *PendingTx, err error) {
+	if nonce == nil {
+		return nil, VoucherNotIssuedErr
+	}
+	issuer.lock.Lock()
+	defer issuer.lock.Unlock()
+	voucher, ok := issuer.vouchers[nonce.String()]
+	if !ok {
+		return nil, VoucherNotIssuedErr
+	}
+	if issuer.revoked[nonce.String()] {
+		return nil, VoucherRevokedErr
+	}
+	used, err := issuer.manager.IsNonceUsed(nonce)
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		// the nonce may be used by a self-claim sent before
+		revoked, err := issuer.checkRevoking(ctx, voucher)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, VoucherRevokedErr
+		}
+		return nil, NonceUsedErr
+	}
+	pending, err = issuer.manager.spendNonceTx(ctx, nonce, issuer.signer)
+	if err != nil {
+		return nil, err
+	}
+	entry := ClaimAuditEntry{Time: time.Now(), Action: ClaimRevoking, Voucher: *voucher, TxHash: pending.Hash()}
+	if err := issuer.log.Append(entry); err != nil {
+		return pending, err
+	}
+	issuer.apply(entry)
+	return pending, nil
+}
+
+// checkRevoking logs the revocation of the voucher if one of its logged self-claims is mined successfully
+func (issuer *ClaimIssuer) checkRevoking(ctx context.Context, voucher *ClaimVoucher) (revoked bool, err error) {
+	for _, txHash := range issuer.revoking[voucher.Nonce.String()] {
+		receipt, err := issuer.manager.backend.TransactionReceipt(ctx, common.HexToHash(string(txHash)))
+		if err == ethereum.NotFound {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		if receipt.Status == types.ReceiptStatusSuccessful {
+			return true, issuer.logRevoked(voucher, txHash)
+		}
+	}
+	return false, nil
+}
+
+// logRevoked logs the revocation of the voucher by the confirmed self-claim, which must be called with the lock held
+func (issuer *ClaimIssuer) logRevoked(voucher *ClaimVoucher, txHash Hash) error {
+	if issuer.revoked[voucher.Nonce.String()] {
+		return nil
+	}
+	entry := ClaimAuditEntry{Time: time.Now(), Action: ClaimRevoked, Voucher: *voucher, TxHash: txHash}
+	if err := issuer.log.Append(entry); err != nil {
+		return err
+	}
+	issuer.apply(entry)
+	return nil
+}
+
+/**
+Revoke is the same as RevokeTx, except that the confirmation of the transaction is notified via channels,
+and the revocation is logged once the transaction is confirmed successfully.
+*/
+func (issuer *ClaimIssuer) Revoke(ctx context.Context, nonce *big.Int) (doneCh chan interface{}, errCh chan error) {
+	pending, err := issuer.RevokeTx(ctx, nonce)
+	if err != nil {
+		return asyncDone(ctx, nil, err)
+	}
+	doneCh = make(chan interface{}, 1)
+	errCh = make(chan error, 1)
+	go func() {
+		receipt, err := pending.Wait(ctx, ConfirmationRequirement)
+		if err == nil {
+			err = pending.RevertError(ctx)
+		}
+		if err == nil {
+			issuer.lock.Lock()
+			err = issuer.logRevoked(issuer.vouchers[nonce.String()], Hash(receipt.TxHash.Hex()))
+			issuer.lock.Unlock()
+		}
+		if err != nil {
+			errCh <- err
+			return
+		}
+		close(doneCh)
+	}()
+	return doneCh, errCh
+}
+
+func (issuer *ClaimIssuer) RevokeSync(ctx context.Context, nonce *big.Int) (err error) {
+	doneCh, errCh := issuer.Revoke(ctx, nonce)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
@@ -0,0 +1,248 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClaimIssuer(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	admin := PredefinedAccounts[0]
+	alice := PredefinedAccounts[2]
+	bob := PredefinedAccounts[3]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+
+	dir, err := ioutil.TempDir("", "claim-audit")
+	checkError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "audit.log")
+	log, err := OpenFileClaimAuditLog(path)
+	checkError(t, err)
+	limits := ClaimIssuanceLimits{PerRecipient: big.NewInt(10), Total: big.NewInt(15)}
+	issuer, err := manager.NewClaimIssuer(admin, log, limits)
+	checkError(t, err)
+
+	vouchers, err := issuer.Issue(ctx, []ClaimRequest{
+		{Recipient: alice.Address(), Amount: big.NewInt(5)},
+		{Recipient: bob.Address(), Amount: big.NewInt(4)},
+	})
+	checkError(t, err)
+	if len(vouchers) != 2 || vouchers[0].Nonce.Cmp(vouchers[1].Nonce) == 0 {
+		t.Fatal("vouchers are incorrect", vouchers)
+	}
+	for _, voucher := range vouchers {
+		verdict, err := manager.VerifyTFCClaim(voucher.Recipient, voucher.Amount, voucher.Nonce, voucher.Signature.String())
+		checkError(t, err)
+		if !verdict.Claimable() {
+			t.Fatal("issued voucher should be claimable, got", verdict)
+		}
+	}
+
+	// the limits apply to the batch as a whole
+	if _, err := issuer.Issue(ctx, []ClaimRequest{{Recipient: alice.Address(), Amount: big.NewInt(6)}}); err != ClaimLimitExceededErr {
+		t.Fatal("expect ClaimLimitExceededErr of recipient, got", err)
+	}
+	_, err = issuer.Issue(ctx, []ClaimRequest{
+		{Recipient: bob.Address(), Amount: big.NewInt(5)},
+		{Recipient: alice.Address(), Amount: big.NewInt(2)},
+	})
+	if err != ClaimLimitExceededErr {
+		t.Fatal("expect ClaimLimitExceededErr of total, got", err)
+	}
+
+	// the issuance survives restart
+	checkError(t, log.Close())
+	log, err = OpenFileClaimAuditLog(path)
+	checkError(t, err)
+	defer log.Close()
+	issuer, err = manager.NewClaimIssuer(admin, log, limits)
+	checkError(t, err)
+	if _, err := issuer.Issue(ctx, []ClaimRequest{{Recipient: alice.Address(), Amount: big.NewInt(6)}}); err != ClaimLimitExceededErr {
+		t.Fatal("expect ClaimLimitExceededErr after restart, got", err)
+	}
+
+	// revoked voucher cannot be redeemed
+	revoked := vouchers[1]
+	checkError(t, issuer.RevokeSync(ctx, revoked.Nonce))
+	if err := manager.ClaimTFCWithVoucherSync(ctx, revoked, bob); err != NonceUsedErr {
+		t.Fatal("revoked voucher should not be redeemed, got", err)
+	}
+	if err := issuer.RevokeSync(ctx, revoked.Nonce); err != VoucherRevokedErr {
+		t.Fatal("expect VoucherRevokedErr, got", err)
+	}
+	if err := issuer.RevokeSync(ctx, big.NewInt(1000)); err != VoucherNotIssuedErr {
+		t.Fatal("expect VoucherNotIssuedErr, got", err)
+	}
+	// redeemed voucher cannot be revoked
+	checkError(t, manager.ClaimTFCWithVoucherSync(ctx, vouchers[0], alice))
+	if err := issuer.RevokeSync(ctx, vouchers[0].Nonce); err != NonceUsedErr {
+		t.Fatal("expect NonceUsedErr, got", err)
+	}
+
+	entries, err := log.Load()
+	checkError(t, err)
+	if len(entries) != 4 || entries[0].Action != ClaimIssued || entries[1].Action != ClaimIssued ||
+		entries[2].Action != ClaimRevoking || entries[3].Action != ClaimRevoked ||
+		entries[3].Voucher.Nonce.Cmp(revoked.Nonce) != 0 || entries[3].TxHash == "" || entries[3].TxHash != entries[2].TxHash {
+		t.Fatal("audit log is incorrect", entries)
+	}
+}
+
+func TestClaimIssuer_RevokeTx(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	crashing := &crashingBackend{MockBackend: mockEth.Backend}
+	sdk := NewSDKWithBackend(crashing)
+	admin := PredefinedAccounts[0]
+	alice := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+	log := NewMemoryClaimAuditLog()
+	issuer, err := manager.NewClaimIssuer(admin, log, ClaimIssuanceLimits{})
+	checkError(t, err)
+	vouchers, err := issuer.Issue(ctx, []ClaimRequest{{Recipient: alice.Address(), Amount: big.NewInt(1)}})
+	checkError(t, err)
+	nonce := vouchers[0].Nonce
+
+	// nothing is logged if the self-claim cannot be sent, which can be retried
+	crashing.crashed = true
+	if _, err := issuer.RevokeTx(ctx, nonce); err == nil {
+		t.Fatal("revocation should fail while crashed")
+	}
+	crashing.crashed = false
+	pending, err := issuer.RevokeTx(ctx, nonce)
+	checkError(t, err)
+	entries, err := log.Load()
+	checkError(t, err)
+	if len(entries) != 2 || entries[1].Action != ClaimRevoking || entries[1].TxHash != pending.Hash() {
+		t.Fatal("self-claim should be logged as revoking", entries)
+	}
+
+	// the revocation is logged once the self-claim is confirmed
+	_, err = pending.Wait(ctx, 0)
+	checkError(t, err)
+	if _, err := issuer.RevokeTx(ctx, nonce); err != VoucherRevokedErr {
+		t.Fatal("expect VoucherRevokedErr, got", err)
+	}
+	entries, err = log.Load()
+	checkError(t, err)
+	if len(entries) != 3 || entries[2].Action != ClaimRevoked || entries[2].TxHash != pending.Hash() {
+		t.Fatal("revocation should be logged", entries)
+	}
+	if err := manager.ClaimTFCWithVoucherSync(ctx, vouchers[0], alice); err != NonceUsedErr {
+		t.Fatal("revoked voucher should not be redeemed, got", err)
+	}
+}
+
+// callCountingBackend counts the contract calls, e.g. of IsNonceUsed
+type callCountingBackend struct {
+	*MockBackend
+	calls int32
+}
+
+func (b *callCountingBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	atomic.AddInt32(&b.calls, 1)
+	return b.MockBackend.CallContract(ctx, call, blockNumber)
+}
+
+func TestClaimIssuer_restart(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	counting := &callCountingBackend{MockBackend: mockEth.Backend}
+	sdk := NewSDKWithBackend(counting)
+	admin := PredefinedAccounts[0]
+	alice := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+
+	log := NewMemoryClaimAuditLog()
+	issuer, err := manager.NewClaimIssuer(admin, log, ClaimIssuanceLimits{})
+	checkError(t, err)
+	issued, err := issuer.Issue(ctx, []ClaimRequest{
+		{Recipient: alice.Address(), Amount: big.NewInt(1)},
+		{Recipient: alice.Address(), Amount: big.NewInt(2)},
+	})
+	checkError(t, err)
+	checkError(t, manager.ClaimTFCWithVoucherSync(ctx, issued[0], alice))
+
+	// the issuer is rebuilt from the log with a fresh allocator, as if the process is restarted with in-memory reservations
+	fresh, err := manager.NewNonceAllocator(NewMemoryClaimNonceStore(), 0)
+	checkError(t, err)
+	manager.UseNonceAllocator(fresh)
+	calls := atomic.LoadInt32(&counting.calls)
+	issuer, err = manager.NewClaimIssuer(admin, log, ClaimIssuanceLimits{})
+	checkError(t, err)
+	if atomic.LoadInt32(&counting.calls) != calls {
+		t.Fatal("redeemed vouchers should be known from the claim events instead of contract calls")
+	}
+	if reserved := fresh.Reserved(); len(reserved) != 1 || reserved[0].Cmp(issued[1].Nonce) != 0 {
+		t.Fatal("only the outstanding voucher should be reserved again, got", reserved)
+	}
+	reissued, err := issuer.Issue(ctx, []ClaimRequest{{Recipient: alice.Address(), Amount: big.NewInt(3)}})
+	checkError(t, err)
+	for _, voucher := range issued {
+		if reissued[0].Nonce.Cmp(voucher.Nonce) == 0 {
+			t.Fatal("nonce is issued twice across restart", voucher.Nonce)
+		}
+	}
+}
+
+func TestClaimIssuer_dailyLimits(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	admin := PredefinedAccounts[0]
+	alice := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+
+	// the issuance of yesterday does not count
+	log := NewMemoryClaimAuditLog()
+	checkError(t, log.Append(ClaimAuditEntry{
+		Time:   time.Now().Add(-24 * time.Hour),
+		Action: ClaimIssued,
+		Voucher: ClaimVoucher{
+			Manager:   address,
+			Recipient: alice.Address(),
+			Amount:    big.NewInt(10),
+			Nonce:     big.NewInt(100),
+		},
+	}))
+	issuer, err := manager.NewClaimIssuer(admin, log, ClaimIssuanceLimits{PerRecipient: big.NewInt(10)})
+	checkError(t, err)
+	_, err = issuer.Issue(ctx, []ClaimRequest{{Recipient: alice.Address(), Amount: big.NewInt(10)}})
+	checkError(t, err)
+	if _, err := issuer.Issue(ctx, []ClaimRequest{{Recipient: alice.Address(), Amount: big.NewInt(1)}}); err != ClaimLimitExceededErr {
+		t.Fatal("expect ClaimLimitExceededErr, got", err)
+	}
+}
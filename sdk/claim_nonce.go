@@ -0,0 +1,269 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+)
+
+// ClaimNonceTTL is how long a nonce reserved by ReservePending is kept if it is never handed out (see HandOut)
+var ClaimNonceTTL = time.Hour
+
+/**
+ClaimNonceAllocator hands out unused nonces of TFC claims (see SignTFCClaim), so that concurrent signers never get the same nonce.
+The used nonces are rebuilt from the ClaimTFC events of the Manager contract, which are queried page by page (see QueryWindow)
+and only for the blocks not queried before.
+Each nonce handed out is reserved in the ClaimNonceStore until its claim is seen on chain, or until it is released explicitly,
+since a claim signed with the nonce can be redeemed at any time before the nonce is used.
+A revoked claim (e.g. by ClaimIssuer) is seen on chain as the self-claim spending its nonce.
+Only the nonces reserved by ReservePending which are not handed out within ClaimNonceTTL are released by time,
+e.g. if the process exits before the claim is signed.
+*/
+type ClaimNonceAllocator struct {
+	manager *Manager
+	store   ClaimNonceStore
+
+	lock     sync.Mutex
+	next     uint64               // the first block whose ClaimTFC events have not been queried
+	used     map[string]bool      // nonces redeemed on chain
+	cursor   *big.Int             // no nonce lower than it is unused and unreserved
+	reserved map[string]bool      // nonces reserved
+	pending  map[string]time.Time // reserved nonces not handed out yet => the time they are reserved
+}
+
+/**
+Create a ClaimNonceAllocator of the manager, which restores the reservations from the store.
+fromBlock is the block from which ClaimTFC events are queried, e.g. the block where the Manager contract is deployed.
+*/
+func (manager *Manager) NewNonceAllocator(store ClaimNonceStore, fromBlock uint64) (allocator *ClaimNonceAllocator, err error) {
+	reservations, err := store.Load()
+	if err != nil {
+		return nil, err
+	}
+	allocator = &ClaimNonceAllocator{
+		manager:  manager,
+		store:    store,
+		next:     fromBlock,
+		used:     make(map[string]bool),
+		cursor:   big.NewInt(0),
+		reserved: make(map[string]bool),
+		pending:  make(map[string]time.Time),
+	}
+	for _, reservation := range reservations {
+		allocator.reserved[reservation.Nonce.String()] = true
+		if reservation.Pending {
+			allocator.pending[reservation.Nonce.String()] = reservation.ReservedAt
+		}
+	}
+	return allocator, nil
+}
+
+/**
+Use the allocator for the nonces of this Manager contract handed out by this provider, e.g. one with a persistent ClaimNonceStore.
+*/
+func (manager *Manager) UseNonceAllocator(allocator *ClaimNonceAllocator) {
+	manager.provider.claimNonceLock.Lock()
+	defer manager.provider.claimNonceLock.Unlock()
+	manager.provider.claimNonces[manager.address] = allocator
+}
+
+/**
+Returns the ClaimNonceAllocator of this Manager contract shared by the provider,
+which keeps the reservations in memory if UseNonceAllocator is not called.
+*/
+func (manager *Manager) NonceAllocator() *ClaimNonceAllocator {
+	manager.provider.claimNonceLock.Lock()
+	defer manager.provider.claimNonceLock.Unlock()
+	allocator, ok := manager.provider.claimNonces[manager.address]
+	if !ok {
+		// loading a MemoryClaimNonceStore never fails
+		allocator, _ = manager.NewNonceAllocator(NewMemoryClaimNonceStore(), 0)
+		manager.provider.claimNonces[manager.address] = allocator
+	}
+	return allocator
+}
+
+/**
+Reserve the lowest nonce which is neither redeemed nor reserved, which is handed out to the caller.
+*/
+func (a *ClaimNonceAllocator) Reserve(ctx context.Context) (nonce *big.Int, err error) {
+	return a.reserveNext(ctx, false)
+}
+
+/**
+Reserve the lowest nonce which is neither redeemed nor reserved, before a claim is signed with it.
+The reservation is released after ClaimNonceTTL unless HandOut is called, so the claim must not leave the process before HandOut returns.
+*/
+func (a *ClaimNonceAllocator) ReservePending(ctx context.Context) (nonce *big.Int, err error) {
+	return a.reserveNext(ctx, true)
+}
+
+/**
+Mark the nonce reserved by ReservePending as handed out, so that its reservation is kept until the claim is redeemed.
+NonceNotReservedErr is returned if the nonce is not pending, e.g. if its reservation is released after ClaimNonceTTL,
+then the claim signed with it must be dropped.
+*/
+func (a *ClaimNonceAllocator) HandOut(nonce *big.Int) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	reservedAt, pending := a.pending[nonce.String()]
+	if !pending {
+		return NonceNotReservedErr
+	}
+	if err := a.store.Save(ClaimNonceReservation{Nonce: new(big.Int).Set(nonce), ReservedAt: reservedAt}); err != nil {
+		return err
+	}
+	delete(a.pending, nonce.String())
+	return nil
+}
+
+// reserveNext reserves the lowest nonce which is neither redeemed nor reserved, pending to be handed out or not
+func (a *ClaimNonceAllocator) reserveNext(ctx context.Context, pending bool) (nonce *big.Int, err error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.sync(ctx); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := a.expire(now); err != nil {
+		return nil, err
+	}
+	for nonce = new(big.Int).Set(a.cursor); ; nonce.Add(nonce, big.NewInt(1)) {
+		key := nonce.String()
+		if a.used[key] || a.reserved[key] {
+			continue
+		}
+		// the events of the latest blocks may not be queried yet
+		used, err := a.manager.IsNonceUsed(nonce)
+		if err != nil {
+			return nil, err
+		}
+		if used {
+			a.used[key] = true
+			continue
+		}
+		break
+	}
+	reservation := ClaimNonceReservation{Nonce: nonce, ReservedAt: now, Pending: pending}
+	if err := a.store.Save(reservation); err != nil {
+		return nil, err
+	}
+	a.reserved[nonce.String()] = true
+	if pending {
+		a.pending[nonce.String()] = now
+	}
+	a.cursor = new(big.Int).Add(nonce, big.NewInt(1))
+	return new(big.Int).Set(nonce), nil
+}
+
+/**
+Release the reservation of the nonce, so that it can be handed out again.
+Only release the nonce of a claim which is never handed out, or whose signature is spent (e.g. revoked by a self-claim),
+otherwise the claim may be redeemed instead of the one signed with the nonce again.
+*/
+func (a *ClaimNonceAllocator) Release(nonce *big.Int) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.release(nonce)
+}
+
+// release releases the reservation of the nonce, which must be called with the lock held
+func (a *ClaimNonceAllocator) release(nonce *big.Int) error {
+	if err := a.store.Delete(nonce); err != nil {
+		return err
+	}
+	delete(a.reserved, nonce.String())
+	delete(a.pending, nonce.String())
+	if nonce.Cmp(a.cursor) < 0 {
+		a.cursor = new(big.Int).Set(nonce)
+	}
+	return nil
+}
+
+// expire releases the reservations not handed out within ClaimNonceTTL
+func (a *ClaimNonceAllocator) expire(now time.Time) error {
+	for key, reservedAt := range a.pending {
+		if now.Sub(reservedAt) <= ClaimNonceTTL {
+			continue
+		}
+		nonce, _ := new(big.Int).SetString(key, 10)
+		if err := a.release(nonce); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reserveIssued reserves the nonces handed out before (e.g. of vouchers restored from a ClaimAuditLog), except the redeemed ones,
+// which are known from the ClaimTFC events without checking each nonce on chain
+func (a *ClaimNonceAllocator) reserveIssued(ctx context.Context, nonces []*big.Int) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if err := a.sync(ctx); err != nil {
+		return err
+	}
+	for _, nonce := range nonces {
+		key := nonce.String()
+		if _, pending := a.pending[key]; a.used[key] || a.reserved[key] && !pending {
+			continue
+		}
+		reservation := ClaimNonceReservation{Nonce: new(big.Int).Set(nonce), ReservedAt: time.Now()}
+		if err := a.store.Save(reservation); err != nil {
+			return err
+		}
+		a.reserved[key] = true
+		delete(a.pending, key)
+	}
+	return nil
+}
+
+/**
+Returns the nonces reserved but not redeemed yet.
+*/
+func (a *ClaimNonceAllocator) Reserved() (nonces []*big.Int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for key := range a.reserved {
+		nonce, _ := new(big.Int).SetString(key, 10)
+		nonces = append(nonces, nonce)
+	}
+	return nonces
+}
+
+// sync queries the ClaimTFC events of the blocks not queried before, and drops the reservations of redeemed nonces
+func (a *ClaimNonceAllocator) sync(ctx context.Context) error {
+	head, err := a.manager.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if head.Number.Uint64() < a.next {
+		return nil
+	}
+	it, err := a.manager.QueryClaims(ctx, "", a.next, head.Number.Uint64())
+	if err != nil {
+		return err
+	}
+	for it.Next() {
+		if it.Event().Removed {
+			continue
+		}
+		nonce := it.Event().Nonce
+		a.used[nonce.String()] = true
+		if a.reserved[nonce.String()] {
+			if err := a.store.Delete(nonce); err != nil {
+				return err
+			}
+			delete(a.reserved, nonce.String())
+			delete(a.pending, nonce.String())
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	a.next = head.Number.Uint64() + 1
+	for a.used[a.cursor.String()] {
+		a.cursor = new(big.Int).Add(a.cursor, big.NewInt(1))
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"math/big"
+	"sync"
+	"time"
+)
+
+/**
+ClaimNonceReservation is a nonce of TFC claims reserved by ClaimNonceAllocator, which has not been redeemed yet.
+Pending reservations are not handed out yet, and are released after ClaimNonceTTL (see ReservePending).
+*/
+type ClaimNonceReservation struct {
+	Nonce      *big.Int  `json:"nonce"`
+	ReservedAt time.Time `json:"reservedAt"`
+	Pending    bool      `json:"pending,omitempty"`
+}
+
+/**
+ClaimNonceStore persists the reservations of ClaimNonceAllocator.
+*/
+type ClaimNonceStore interface {
+	// Save creates or overwrites the reservation, which must be durable when it returns
+	Save(reservation ClaimNonceReservation) error
+	// Delete deletes the reservation of the nonce
+	Delete(nonce *big.Int) error
+	// Load returns all saved reservations
+	Load() ([]ClaimNonceReservation, error)
+}
+
+/**
+MemoryClaimNonceStore keeps the reservations in memory, which are lost when the process exits.
+*/
+type MemoryClaimNonceStore struct {
+	lock         sync.Mutex
+	reservations map[string]ClaimNonceReservation
+}
+
+func NewMemoryClaimNonceStore() *MemoryClaimNonceStore {
+	return &MemoryClaimNonceStore{reservations: make(map[string]ClaimNonceReservation)}
+}
+
+func (s *MemoryClaimNonceStore) Save(reservation ClaimNonceReservation) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.reservations[reservation.Nonce.String()] = reservation
+	return nil
+}
+
+func (s *MemoryClaimNonceStore) Delete(nonce *big.Int) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.reservations, nonce.String())
+	return nil
+}
+
+func (s *MemoryClaimNonceStore) Load() ([]ClaimNonceReservation, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	reservations := make([]ClaimNonceReservation, 0, len(s.reservations))
+	for _, reservation := range s.reservations {
+		reservations = append(reservations, reservation)
+	}
+	return reservations, nil
+}
+
+// key prefix of reservations in LevelDBClaimNonceStore
+var claimNoncePrefix = []byte("claim-nonce-")
+
+/**
+LevelDBClaimNonceStore persists the reservations as JSON in a LevelDB database on disk, and every write is synced before it returns.
+The database can only be opened by one process at a time.
+*/
+type LevelDBClaimNonceStore struct {
+	db *leveldb.DB
+}
+
+/**
+Open (or create) the LevelDBClaimNonceStore in the directory.
+*/
+func OpenLevelDBClaimNonceStore(dir string) (store *LevelDBClaimNonceStore, err error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBClaimNonceStore{db: db}, nil
+}
+
+func (s *LevelDBClaimNonceStore) Close() error {
+	return s.db.Close()
+}
+
+func claimNonceKey(nonce *big.Int) []byte {
+	return append(append([]byte{}, claimNoncePrefix...), nonce.String()...)
+}
+
+func (s *LevelDBClaimNonceStore) Save(reservation ClaimNonceReservation) error {
+	value, err := json.Marshal(reservation)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(claimNonceKey(reservation.Nonce), value, syncWrite)
+}
+
+func (s *LevelDBClaimNonceStore) Delete(nonce *big.Int) error {
+	return s.db.Delete(claimNonceKey(nonce), syncWrite)
+}
+
+func (s *LevelDBClaimNonceStore) Load() ([]ClaimNonceReservation, error) {
+	it := s.db.NewIterator(util.BytesPrefix(claimNoncePrefix), nil)
+	defer it.Release()
+	var reservations []ClaimNonceReservation
+	for it.Next() {
+		var reservation ClaimNonceReservation
+		if err := json.Unmarshal(it.Value(), &reservation); err != nil {
+			return nil, err
+		}
+		reservations = append(reservations, reservation)
+	}
+	return reservations, it.Error()
+}
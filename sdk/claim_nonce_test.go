@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClaimNonceAllocator(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	defaultWindow := QueryWindow
+	QueryWindow = 2
+	defer func() { QueryWindow = defaultWindow }()
+
+	ctx := context.Background()
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[2]
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+	claim := func(nonce int64) {
+		sig, err := manager.SignTFCClaim(user.Address(), big.NewInt(1), big.NewInt(nonce), admin)
+		checkError(t, err)
+		checkError(t, manager.ClaimTFCSync(ctx, big.NewInt(1), big.NewInt(nonce), sig, user))
+	}
+	// claims redeemed before the allocator starts
+	claim(0)
+	mockEth.Backend.Commit()
+	claim(2)
+
+	dir, err := ioutil.TempDir("", "nonces")
+	checkError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := OpenLevelDBClaimNonceStore(dir)
+	checkError(t, err)
+	allocator, err := manager.NewNonceAllocator(store, 0)
+	checkError(t, err)
+
+	// concurrent reservations never collide, and skip redeemed nonces
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var nonces []int
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := allocator.Reserve(ctx)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			lock.Lock()
+			nonces = append(nonces, int(nonce.Int64()))
+			lock.Unlock()
+		}()
+	}
+	wg.Wait()
+	sort.Ints(nonces)
+	expected := []int{1, 3, 4, 5, 6}
+	for i := range expected {
+		if len(nonces) != len(expected) || nonces[i] != expected[i] {
+			t.Fatal("reserved nonces are incorrect, got", nonces)
+		}
+	}
+
+	// the reservation is dropped once the claim is redeemed
+	claim(1)
+	nonce, err := allocator.Reserve(ctx)
+	checkError(t, err)
+	if nonce.Int64() != 7 || len(allocator.Reserved()) != 5 {
+		t.Fatal("redeemed reservation should be dropped, got", nonce, allocator.Reserved())
+	}
+	checkError(t, allocator.Release(big.NewInt(4)))
+	checkError(t, store.Close())
+
+	// the reservations survive restart
+	store, err = OpenLevelDBClaimNonceStore(dir)
+	checkError(t, err)
+	defer store.Close()
+	allocator, err = manager.NewNonceAllocator(store, 0)
+	checkError(t, err)
+	nonce, err = allocator.Reserve(ctx)
+	checkError(t, err)
+	if nonce.Int64() != 4 {
+		t.Fatal("released nonce should be reserved again, got", nonce)
+	}
+	nonce, err = allocator.Reserve(ctx)
+	checkError(t, err)
+	if nonce.Int64() != 8 {
+		t.Fatal("persisted reservations should not be handed out again, got", nonce)
+	}
+
+	// reservations handed out are never released by time, since the claims signed with them can still be redeemed
+	checkError(t, store.Save(ClaimNonceReservation{Nonce: big.NewInt(9), ReservedAt: time.Now().Add(-365 * 24 * time.Hour)}))
+	allocator, err = manager.NewNonceAllocator(store, 0)
+	checkError(t, err)
+	nonce, err = allocator.Reserve(ctx)
+	checkError(t, err)
+	if nonce.Int64() != 10 {
+		t.Fatal("old reservations should not be handed out again, got", nonce)
+	}
+
+	// pending reservations are released after the TTL unless they are handed out
+	pending, err := allocator.ReservePending(ctx)
+	checkError(t, err)
+	handedOut, err := allocator.ReservePending(ctx)
+	checkError(t, err)
+	checkError(t, allocator.HandOut(handedOut))
+	ClaimNonceTTL = time.Millisecond
+	defer func() { ClaimNonceTTL = time.Hour }()
+	time.Sleep(5 * time.Millisecond)
+	nonce, err = allocator.Reserve(ctx)
+	checkError(t, err)
+	if nonce.Cmp(pending) != 0 {
+		t.Fatal("pending reservation should be released after the TTL, got", nonce, pending)
+	}
+	if err := allocator.HandOut(pending); err != NonceNotReservedErr {
+		t.Fatal("expect NonceNotReservedErr, got", err)
+	}
+	nonce, err = allocator.Reserve(ctx)
+	checkError(t, err)
+	if nonce.Cmp(handedOut) <= 0 {
+		t.Fatal("handed out reservation should not be released, got", nonce, handedOut)
+	}
+	reservations, err := store.Load()
+	checkError(t, err)
+	for _, reservation := range reservations {
+		if reservation.Pending {
+			t.Fatal("no reservation should be pending, got", reservation.Nonce)
+		}
+	}
+}
+
+func TestManager_GetUnusedNonce(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployManagerSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+
+	// the Manager instances of the same contract share the allocator
+	seen := make(map[int64]bool)
+	for i := 0; i < 3; i++ {
+		manager, err := sdk.Manager(address)
+		checkError(t, err)
+		nonce, err := manager.GetUnusedNonce()
+		checkError(t, err)
+		if seen[nonce.Int64()] {
+			t.Fatal("nonce is handed out twice", nonce)
+		}
+		seen[nonce.Int64()] = true
+	}
+}
@@ -1,6 +1,11 @@
 package sdk
 
-import "errors"
+import (
+	"errors"
+	"github.com/Troublor/jasmine-eth-go/token"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"strings"
+)
 
 var ConfirmationRequirement = 0
 
@@ -17,6 +22,11 @@ var PredefinedPrivateKeys = []string{
 	"0xb0057716d5917badaf911b193b12b910811c1497b5bada8d7711f758981c3773",
 }
 
+var (
+	tfcTokenABI, _   = abi.JSON(strings.NewReader(token.TFCTokenABI))
+	tfcManagerABI, _ = abi.JSON(strings.NewReader(token.TFCManagerABI))
+)
+
 var PredefinedAccounts = make([]*Account, len(PredefinedPrivateKeys))
 
 func init() {
@@ -32,4 +42,44 @@ var (
 	InsufficientGasErr            = errors.New("insufficient gas for transaction")
 	InsufficientTransactionFeeErr = errors.New("transaction fee is not enough to cover gas * gas price")
 	InvalidDepositErr             = errors.New("transaction fee deposit is invalid")
+	TokenPausedErr                = errors.New("token is paused")
+	InvalidAmountErr              = errors.New("amount must be non-negative")
+	SkippedBatchChunkErr          = errors.New("batch chunk is not sent because a previous chunk failed")
+	InvalidBlockRangeErr          = errors.New("fromBlock must not be greater than toBlock")
+	TransactionDroppedErr         = errors.New("transaction is dropped")
+	SubscriptionClosedErr         = errors.New("subscription is closed")
+	TransactionNotPendingErr      = errors.New("transaction is not pending")
+	TransactionReplacedErr        = errors.New("transaction is replaced by another transaction with the same nonce")
+	TransactionCancelledErr       = errors.New("transaction is cancelled")
+	NotTransactionSenderErr       = errors.New("account is not the sender of the transaction")
+	GasPriceLimitErr              = errors.New("gas price exceeds the limit")
+	DynamicFeeNotSupportedErr     = errors.New("chain does not support dynamic fee transactions")
+	SignedTransactionMismatchErr  = errors.New("signed transaction does not match the requested one")
+	InvalidSignatureErr           = errors.New("invalid signature")
+	SignerMismatchErr             = errors.New("signature is not signed by the account of the signer")
+	PrivateKeyUnavailableErr      = errors.New("private key of the account is not available in process")
+	InvalidMnemonicErr            = errors.New("invalid mnemonic")
+	InvalidDerivationErr          = errors.New("derived key is invalid, use another index")
+	InvalidExtendedKeyErr         = errors.New("invalid extended public key")
+	UnknownDepositOrderErr        = errors.New("unknown deposit order")
+	DuplicateDepositOrderErr      = errors.New("deposit order or index already exists")
+	DepositNotFoundErr            = errors.New("no deposit is received by the deposit address")
+	DepositKeyMismatchErr         = errors.New("account is not the owner of the deposit address")
+	DepositUsedErr                = errors.New("deposit transaction has been used")
+	InvalidExchangeConfigErr      = errors.New("bridge account and deposit addresses of exchange are required")
+	InvalidQuoteErr               = errors.New("quote is altered or not signed by the bridge account")
+	QuoteExpiredErr               = errors.New("quote is expired")
+	QuoteUsedErr                  = errors.New("quote has been used")
+	DepositBelowQuoteErr          = errors.New("deposit amount is less than the required transfer amount of the quote")
+	RefundTooSmallErr             = errors.New("refund does not cover its transaction fee")
+	InvalidTokenPriceErr          = errors.New("price of the fee token must be positive")
+	InvalidVoucherErr             = errors.New("claim voucher is malformed")
+	VoucherMismatchErr            = errors.New("claim voucher is addressed to another manager contract or recipient")
+	VoucherExpiredErr             = errors.New("claim voucher is expired")
+	NonceUsedErr                  = errors.New("nonce of the claim has been used")
+	NonceNotReservedErr           = errors.New("nonce of the claim is not reserved pending to be handed out")
+	InvalidTypedDataErr           = errors.New("typed data is invalid")
+	ClaimLimitExceededErr         = errors.New("daily claim issuance limit is exceeded")
+	VoucherNotIssuedErr           = errors.New("claim voucher is not issued by the claim issuer")
+	VoucherRevokedErr             = errors.New("claim voucher has been revoked")
 )
@@ -0,0 +1,418 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sort"
+	"sync"
+)
+
+// a deposit address is swept only if the worst-case transaction fee is at most this percent of its balance
+var MaxSweepFeePercent = 10
+
+// the sweep transactions sent in one batch use at most this percent of the block gas limit
+var SweepBatchGasPercent = 50
+
+/**
+DepositOrder is a bridge exchange order, of which the user deposits the transaction fee to a deposit address dedicated to the order.
+The deposit is mapped to the order by the deposit address instead of the sender,
+so that it can be sent from anywhere, e.g. an exchange or a smart contract wallet.
+*/
+type DepositOrder struct {
+	ID        string
+	Recipient Address // the address receiving TFC of the exchange
+	Index     uint32  // child index of the deposit address in the extended public key
+	Address   Address // the deposit address
+	Settled   bool    // whether the deposit has been credited to the order
+}
+
+// Deposit is the confirmed deposit of an order
+type Deposit struct {
+	Order  DepositOrder
+	Amount *big.Int
+}
+
+// SweepResult is the outcome of sweeping one deposit address to the bridge account
+type SweepResult struct {
+	Order           DepositOrder
+	Amount          *big.Int // value transferred to the bridge account, excluding the transaction fee
+	TransactionHash Hash
+	Transaction     *PendingTx
+	Err             error // nil if the sweep transaction has been confirmed
+}
+
+/**
+DepositAddresses derives a unique deposit address for each order from an extended public key,
+so that the server handing out deposit addresses does not hold any private key.
+The orders are only kept in memory, they should be persisted by the caller and restored by RestoreOrder.
+*/
+type DepositAddresses struct {
+	*provider
+	xpub *ExtendedPublicKey
+
+	lock      sync.Mutex
+	nextIndex uint32
+	orders    map[string]*DepositOrder
+	indexes   map[uint32]*DepositOrder
+	addresses map[common.Address]*DepositOrder
+}
+
+/**
+DepositAddresses returns the deposit addresses derived from the extended public key xpub,
+whose child index is the index of the deposit address.
+The xpub of the accounts derived by DeriveAccount can be obtained by DeriveAccountXPub.
+*/
+func (sdk *SDK) DepositAddresses(xpub string) (deposits *DepositAddresses, err error) {
+	key, err := ParseExtendedPublicKey(xpub)
+	if err != nil {
+		return nil, err
+	}
+	return &DepositAddresses{
+		provider:  sdk.provider,
+		xpub:      key,
+		orders:    make(map[string]*DepositOrder),
+		indexes:   make(map[uint32]*DepositOrder),
+		addresses: make(map[common.Address]*DepositOrder),
+	}, nil
+}
+
+/**
+Returns the deposit address with index.
+*/
+func (d *DepositAddresses) Address(index uint32) (address Address, err error) {
+	child, err := d.xpub.Child(index)
+	if err != nil {
+		return "", err
+	}
+	return child.Address(), nil
+}
+
+/**
+Create an order whose deposit address is derived with the next unused index.
+*/
+func (d *DepositAddresses) NewOrder(id string, recipient Address) (order DepositOrder, err error) {
+	if !recipient.IsValid() {
+		return DepositOrder{}, InvalidAddressError
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, exists := d.orders[id]; exists {
+		return DepositOrder{}, DuplicateDepositOrderErr
+	}
+	for {
+		index := d.nextIndex
+		if _, used := d.indexes[index]; used {
+			d.nextIndex++
+			continue
+		}
+		address, err := d.Address(index)
+		d.nextIndex++
+		if err == InvalidDerivationErr {
+			continue
+		} else if err != nil {
+			return DepositOrder{}, err
+		}
+		order = DepositOrder{
+			ID:        id,
+			Recipient: recipient,
+			Index:     index,
+			Address:   address,
+		}
+		d.add(&order)
+		return order, nil
+	}
+}
+
+/**
+Restore an order created before, e.g. loaded from the database after restart.
+The deposit address of the order is checked against its index.
+*/
+func (d *DepositAddresses) RestoreOrder(order DepositOrder) (err error) {
+	if !order.Recipient.IsValid() {
+		return InvalidAddressError
+	}
+	address, err := d.Address(order.Index)
+	if err != nil {
+		return err
+	}
+	if order.Address.address() != address.address() {
+		return DepositKeyMismatchErr
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if _, exists := d.orders[order.ID]; exists {
+		return DuplicateDepositOrderErr
+	}
+	if _, used := d.indexes[order.Index]; used {
+		return DuplicateDepositOrderErr
+	}
+	d.add(&order)
+	return nil
+}
+
+func (d *DepositAddresses) add(order *DepositOrder) {
+	d.orders[order.ID] = order
+	d.indexes[order.Index] = order
+	d.addresses[order.Address.address()] = order
+	if order.Index >= d.nextIndex {
+		d.nextIndex = order.Index + 1
+	}
+}
+
+/**
+Returns the order with id.
+*/
+func (d *DepositAddresses) Order(id string) (order DepositOrder, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	o, ok := d.orders[id]
+	if !ok {
+		return DepositOrder{}, UnknownDepositOrderErr
+	}
+	return *o, nil
+}
+
+/**
+Returns the order of the deposit address.
+*/
+func (d *DepositAddresses) OrderOf(address Address) (order DepositOrder, err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	o, ok := d.addresses[address.address()]
+	if !ok {
+		return DepositOrder{}, UnknownDepositOrderErr
+	}
+	return *o, nil
+}
+
+/**
+Returns all orders sorted by index.
+*/
+func (d *DepositAddresses) Orders() (orders []DepositOrder) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, o := range d.orders {
+		orders = append(orders, *o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].Index < orders[j].Index
+	})
+	return orders
+}
+
+/**
+Mark the order as settled once its deposit is credited (e.g. the TFC is minted), so that its deposit address can be swept.
+*/
+func (d *DepositAddresses) Settle(id string) (err error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	o, ok := d.orders[id]
+	if !ok {
+		return UnknownDepositOrderErr
+	}
+	o.Settled = true
+	return nil
+}
+
+/**
+Check the deposit of the order, which is the balance of its deposit address with depositConfirmationRequirement confirmations.
+It replaces CheckTransactionFeeDeposit for orders with deposit addresses, and returns the recipient of the order.
+
+UnconfirmedTransactionErr is returned (with the unconfirmed amount) if the deposit does not have enough confirmations,
+and DepositNotFoundErr is returned if nothing is deposited.
+Note that the deposit address is emptied once swept, so the deposit must be checked before the order is settled.
+*/
+func (d *DepositAddresses) CheckOrderDeposit(ctx context.Context, id string, depositConfirmationRequirement int) (recipient Address, depositAmount *big.Int, err error) {
+	order, err := d.Order(id)
+	if err != nil {
+		return "", nil, err
+	}
+	confirmed, latest, err := d.depositBalance(ctx, order.Address, depositConfirmationRequirement)
+	if err != nil {
+		return "", nil, err
+	}
+	if confirmed.Sign() > 0 {
+		return order.Recipient, confirmed, nil
+	}
+	if latest.Sign() > 0 {
+		return "", latest, UnconfirmedTransactionErr
+	}
+	return "", nil, DepositNotFoundErr
+}
+
+/**
+Returns the confirmed deposits (see CheckOrderDeposit) of all unsettled orders, sorted by index.
+Orders without confirmed deposit are omitted.
+*/
+func (d *DepositAddresses) Deposits(ctx context.Context, depositConfirmationRequirement int) (deposits []Deposit, err error) {
+	for _, order := range d.Orders() {
+		if order.Settled {
+			continue
+		}
+		confirmed, _, err := d.depositBalance(ctx, order.Address, depositConfirmationRequirement)
+		if err != nil {
+			return nil, err
+		}
+		if confirmed.Sign() > 0 {
+			deposits = append(deposits, Deposit{Order: order, Amount: confirmed})
+		}
+	}
+	return deposits, nil
+}
+
+// depositBalance returns the balance of address with confirmations, and the latest balance
+func (d *DepositAddresses) depositBalance(ctx context.Context, address Address, confirmations int) (confirmed *big.Int, latest *big.Int, err error) {
+	header, err := d.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	latest, err = d.backend.BalanceAt(ctx, address.address(), header.Number)
+	if err != nil {
+		return nil, nil, err
+	}
+	blockNumber := new(big.Int).Sub(header.Number, big.NewInt(int64(confirmations)))
+	if blockNumber.Sign() < 0 {
+		return big.NewInt(0), latest, nil
+	}
+	confirmed, err = d.backend.BalanceAt(ctx, address.address(), blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	return confirmed, latest, nil
+}
+
+/**
+Sweep the balances of the deposit addresses of settled orders to the bridge account.
+
+The transaction fee of each sweep is paid by the deposit address itself,
+and a deposit address is skipped if the worst-case fee is more than MaxSweepFeePercent of its balance,
+so that small balances are left until the gas price drops.
+The sweep transactions are sent in batches, each of which uses at most SweepBatchGasPercent of the block gas limit,
+and a batch is sent after the previous one is confirmed.
+Sweep transactions are not bumped automatically, since a higher fee would exceed the balance.
+
+keys returns the account of the deposit address with index, e.g. by DeriveAccount with the mnemonic of the extended public key.
+It blocks until all sweep transactions are confirmed (or failed) and returns one result for each swept deposit address.
+The returned err is non-nil only if the sweep cannot be planned, in which case no transaction is sent.
+*/
+func (d *DepositAddresses) Sweep(ctx context.Context, bridgeAccountAddress Address, keys func(index uint32) (*Account, error)) (results []SweepResult, err error) {
+	if !bridgeAccountAddress.IsValid() {
+		return nil, InvalidAddressError
+	}
+	bridge := bridgeAccountAddress.address()
+	fees, err := d.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header, err := d.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	batchGas := header.GasLimit * uint64(SweepBatchGasPercent) / 100
+
+	// plan the sweep of each deposit address
+	var accounts []*Account
+	var gasLimits []uint64
+	for _, order := range d.Orders() {
+		if !order.Settled {
+			continue
+		}
+		balance, err := d.backend.BalanceAt(ctx, order.Address.address(), nil)
+		if err != nil {
+			return nil, err
+		}
+		if balance.Sign() == 0 {
+			continue
+		}
+		gas, err := d.backend.EstimateGas(ctx, ethereum.CallMsg{From: order.Address.address(), To: &bridge, Value: balance})
+		if err != nil {
+			return nil, err
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(gas), fees.MaxGasPrice())
+		maxFee := new(big.Int).Mul(balance, big.NewInt(int64(MaxSweepFeePercent)))
+		if new(big.Int).Mul(fee, big.NewInt(100)).Cmp(maxFee) > 0 {
+			continue
+		}
+		result := SweepResult{Order: order, Amount: balance.Sub(balance, fee)}
+		account, err := keys(order.Index)
+		if err != nil {
+			result.Err = err
+		} else if account.address != order.Address.address() {
+			result.Err = DepositKeyMismatchErr
+		}
+		results = append(results, result)
+		accounts = append(accounts, account)
+		gasLimits = append(gasLimits, gas)
+	}
+
+	chainID, err := d.chainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// send in batches fitting in the block gas limit
+	for start := 0; start < len(results); {
+		end, used := start, uint64(0)
+		for end < len(results) && (end == start || used+gasLimits[end] <= batchGas) {
+			used += gasLimits[end]
+			end++
+		}
+		for i := start; i < end; i++ {
+			if results[i].Err != nil {
+				continue
+			}
+			value, gas := results[i].Amount, gasLimits[i]
+			pending, err := d.transactWithFees(ctx, accounts[i], fees, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+				signed, err := auth.Signer(auth.From, transferTx(chainID, auth, bridge, value, gas))
+				if err != nil {
+					return nil, err
+				}
+				if err := d.backend.SendTransaction(ctx, signed); err != nil {
+					return nil, err
+				}
+				return signed, nil
+			})
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			pending.SetBumpPolicy(nil)
+			results[i].Transaction = pending
+			results[i].TransactionHash = pending.Hash()
+		}
+		// wait for the batch before sending the next one
+		for i := start; i < end; i++ {
+			if results[i].Err != nil {
+				continue
+			}
+			_, results[i].Err = results[i].Transaction.Wait(ctx, ConfirmationRequirement)
+		}
+		start = end
+	}
+	return results, nil
+}
+
+// transferTx returns the unsigned transaction transferring value to the address with the nonce and fees of auth
+func transferTx(chainID *big.Int, auth *bind.TransactOpts, to common.Address, value *big.Int, gas uint64) *types.Transaction {
+	if auth.GasFeeCap != nil {
+		return types.NewTx(&types.DynamicFeeTx{
+			ChainID:   chainID,
+			Nonce:     auth.Nonce.Uint64(),
+			GasTipCap: auth.GasTipCap,
+			GasFeeCap: auth.GasFeeCap,
+			Gas:       gas,
+			To:        &to,
+			Value:     value,
+		})
+	}
+	return types.NewTx(&types.LegacyTx{
+		Nonce:    auth.Nonce.Uint64(),
+		GasPrice: auth.GasPrice,
+		Gas:      gas,
+		To:       &to,
+		Value:    value,
+	})
+}
@@ -0,0 +1,229 @@
+package sdk
+
+import (
+	"context"
+	"encoding/hex"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// depositTo sends ETH to the address and returns the hash of the transaction once it is mined
+func depositTo(t *testing.T, backend *MockBackend, from *Account, to Address, amount *big.Int) (txHash string) {
+	nonce, err := backend.PendingNonceAt(context.Background(), from.address)
+	checkError(t, err)
+	gasPrice, err := backend.SuggestGasPrice(context.Background())
+	checkError(t, err)
+	tx := types.NewTransaction(nonce, to.address(), amount, 21000, gasPrice, nil)
+	signed, err := from.signer.SignTx(context.Background(), tx, backend.Blockchain().Config().ChainID)
+	checkError(t, err)
+	checkError(t, backend.SendTransaction(context.Background(), signed))
+	// wait until mined by the mock ethereum
+	for {
+		if _, err := backend.TransactionReceipt(context.Background(), signed.Hash()); err == nil {
+			return signed.Hash().Hex()
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestExtendedPublicKey(t *testing.T) {
+	// test vector 1 of BIP-32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	expected := map[string]string{
+		"m":      "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		"m/0'":   "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		"m/0'/1": "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+	}
+	paths := map[string]accounts.DerivationPath{
+		"m":      {},
+		"m/0'":   {0x80000000},
+		"m/0'/1": {0x80000000, 1},
+	}
+	for path, xpub := range expected {
+		key, err := deriveExtendedKey(seed, paths[path])
+		checkError(t, err)
+		neutered, err := key.neuter()
+		checkError(t, err)
+		if neutered.String() != xpub {
+			t.Fatal("extended public key of", path, "is incorrect, got", neutered.String())
+		}
+	}
+
+	// public derivation of non-hardened children
+	parent, err := ParseExtendedPublicKey(expected["m/0'"])
+	checkError(t, err)
+	child, err := parent.Child(1)
+	checkError(t, err)
+	if child.String() != expected["m/0'/1"] {
+		t.Fatal("public child derivation is incorrect, got", child.String())
+	}
+	_, err = parent.Child(0x80000000)
+	if err != InvalidDerivationErr {
+		t.Fatal("expect InvalidDerivationErr, got", err)
+	}
+	_, err = ParseExtendedPublicKey(expected["m"][:len(expected["m"])-1] + "9")
+	if err != InvalidExtendedKeyErr {
+		t.Fatal("expect InvalidExtendedKeyErr, got", err)
+	}
+
+	// the children of the account xpub are the accounts derived from the mnemonic
+	sdk := &SDK{}
+	xpub, err := sdk.DeriveAccountXPub("myth like bonus scare over problem client lizard pioneer submit female collect")
+	checkError(t, err)
+	key, err := ParseExtendedPublicKey(xpub)
+	checkError(t, err)
+	for i, account := range PredefinedAccounts {
+		child, err := key.Child(uint32(i))
+		checkError(t, err)
+		if child.Address() != account.Address() {
+			t.Fatal("deposit address", i, "is incorrect, got", child.Address())
+		}
+	}
+}
+
+func TestDepositAddresses(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	backend := mockEth.Backend
+	sdk := NewSDKWithBackend(backend)
+	ctx := context.Background()
+
+	mnemonic, err := NewMnemonic()
+	checkError(t, err)
+	xpub, err := sdk.DeriveAccountXPub(mnemonic)
+	checkError(t, err)
+	deposits, err := sdk.DepositAddresses(xpub)
+	checkError(t, err)
+	keys := func(index uint32) (*Account, error) {
+		return sdk.DeriveAccount(mnemonic, index)
+	}
+
+	order, err := deposits.NewOrder("order-0", PredefinedAccounts[2].Address())
+	checkError(t, err)
+	account, err := keys(0)
+	checkError(t, err)
+	if order.Index != 0 || order.Address != account.Address() {
+		t.Fatal("deposit address of the first order is incorrect")
+	}
+	_, err = deposits.NewOrder("order-0", PredefinedAccounts[2].Address())
+	if err != DuplicateDepositOrderErr {
+		t.Fatal("expect DuplicateDepositOrderErr, got", err)
+	}
+	_, _, err = deposits.CheckOrderDeposit(ctx, "order-0", 0)
+	if err != DepositNotFoundErr {
+		t.Fatal("expect DepositNotFoundErr, got", err)
+	}
+
+	// deposits of different senders to the deposit address are added up
+	oneEther := big.NewInt(1000000000000000000)
+	depositTo(t, backend, PredefinedAccounts[0], order.Address, oneEther)
+	depositTo(t, backend, PredefinedAccounts[1], order.Address, oneEther)
+	recipient, amount, err := deposits.CheckOrderDeposit(ctx, "order-0", 0)
+	checkError(t, err)
+	if recipient != PredefinedAccounts[2].Address() || amount.Cmp(new(big.Int).Mul(oneEther, big.NewInt(2))) != 0 {
+		t.Fatal("deposit is incorrect, got", recipient, amount)
+	}
+	_, amount, err = deposits.CheckOrderDeposit(ctx, "order-0", 1)
+	if err != nil || amount.Cmp(oneEther) != 0 {
+		t.Fatal("confirmed deposit is incorrect, got", amount, err)
+	}
+	_, amount, err = deposits.CheckOrderDeposit(ctx, "order-0", 10)
+	if err != UnconfirmedTransactionErr || amount.Cmp(new(big.Int).Mul(oneEther, big.NewInt(2))) != 0 {
+		t.Fatal("expect UnconfirmedTransactionErr, got", err)
+	}
+
+	// more orders, one of which only has dust
+	dustOrder, err := deposits.NewOrder("order-1", PredefinedAccounts[3].Address())
+	checkError(t, err)
+	depositTo(t, backend, PredefinedAccounts[0], dustOrder.Address, big.NewInt(1000))
+	var orders []DepositOrder
+	for _, id := range []string{"order-2", "order-3"} {
+		o, err := deposits.NewOrder(id, PredefinedAccounts[4].Address())
+		checkError(t, err)
+		depositTo(t, backend, PredefinedAccounts[0], o.Address, oneEther)
+		orders = append(orders, o)
+	}
+	found, err := deposits.Deposits(ctx, 0)
+	checkError(t, err)
+	if len(found) != 4 || found[1].Order.ID != "order-1" || found[1].Amount.Int64() != 1000 {
+		t.Fatal("deposits are incorrect, got", found)
+	}
+	if o, err := deposits.OrderOf(orders[0].Address); err != nil || o.ID != "order-2" {
+		t.Fatal("order of the deposit address is incorrect")
+	}
+
+	// only deposit addresses of settled orders are swept
+	bridge := PredefinedAccounts[5].Address()
+	results, err := deposits.Sweep(ctx, bridge, keys)
+	checkError(t, err)
+	if len(results) != 0 {
+		t.Fatal("unsettled orders should not be swept")
+	}
+	for _, id := range []string{"order-0", "order-1", "order-2", "order-3"} {
+		checkError(t, deposits.Settle(id))
+	}
+	found, err = deposits.Deposits(ctx, 0)
+	checkError(t, err)
+	if len(found) != 0 {
+		t.Fatal("settled orders should not have deposits")
+	}
+
+	// the sweeps are sent in batches of two transactions
+	SweepBatchGasPercent = 1
+	defer func() {
+		SweepBatchGasPercent = 50
+	}()
+	before, err := backend.BalanceAt(ctx, bridge.address(), nil)
+	checkError(t, err)
+	results, err = deposits.Sweep(ctx, bridge, keys)
+	checkError(t, err)
+	if len(results) != 3 {
+		t.Fatal("expect 3 deposit addresses swept without the dust one, got", len(results))
+	}
+	swept := big.NewInt(0)
+	for _, result := range results {
+		checkError(t, result.Err)
+		if result.Order.ID == "order-1" {
+			t.Fatal("dust should not be swept")
+		}
+		swept.Add(swept, result.Amount)
+	}
+	if results[2].Transaction.Receipt().BlockNumber.Cmp(results[1].Transaction.Receipt().BlockNumber) <= 0 {
+		t.Fatal("the second batch should be sent after the first one is mined")
+	}
+	after, err := backend.BalanceAt(ctx, bridge.address(), nil)
+	checkError(t, err)
+	if new(big.Int).Sub(after, before).Cmp(swept) != 0 {
+		t.Fatal("bridge account should receive the swept amount")
+	}
+
+	// orders are restored with their indexes after restart
+	restored, err := sdk.DepositAddresses(xpub)
+	checkError(t, err)
+	checkError(t, restored.RestoreOrder(orders[1]))
+	wrong := orders[0]
+	wrong.Index = 7
+	if err := restored.RestoreOrder(wrong); err != DepositKeyMismatchErr {
+		t.Fatal("expect DepositKeyMismatchErr, got", err)
+	}
+	next, err := restored.NewOrder("order-4", PredefinedAccounts[4].Address())
+	checkError(t, err)
+	if next.Index != orders[1].Index+1 {
+		t.Fatal("new order should not reuse restored indexes")
+	}
+
+	// the keys must match the deposit addresses
+	depositTo(t, backend, PredefinedAccounts[0], next.Address, oneEther)
+	checkError(t, restored.Settle("order-4"))
+	results, err = restored.Sweep(ctx, bridge, func(index uint32) (*Account, error) {
+		return PredefinedAccounts[0], nil
+	})
+	checkError(t, err)
+	if len(results) != 1 || results[0].Err != DepositKeyMismatchErr {
+		t.Fatal("expect DepositKeyMismatchErr")
+	}
+}
@@ -7,4 +7,21 @@ var (
 	NoPrivateKeyError      = errors.New("no private key is provided")
 	InvalidPrivateKeyError = errors.New("invalid private key")
 	InvalidAddressError    = errors.New("invalid Ethereum address")
+	InvalidRoleError       = errors.New("invalid role")
 )
+
+/**
+RevertedError is returned when a transaction is mined but its execution is reverted.
+Reason is the message of Solidity Error(string), which is empty if it cannot be decoded.
+*/
+type RevertedError struct {
+	TxHash Hash
+	Reason string
+}
+
+func (e *RevertedError) Error() string {
+	if e.Reason == "" {
+		return "transaction " + string(e.TxHash) + " reverted"
+	}
+	return "transaction " + string(e.TxHash) + " reverted: " + e.Reason
+}
@@ -0,0 +1,388 @@
+package sdk
+
+import (
+	"context"
+	"github.com/Troublor/jasmine-eth-go/token"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+// EventMeta is the on-chain position of an event log
+type EventMeta struct {
+	BlockNumber     uint64
+	BlockHash       Hash
+	TransactionHash Hash
+	LogIndex        uint
+	// Removed is true if the log is reverted due to a chain reorganisation.
+	// The same event will be emitted again with Removed being false if it is included in the new canonical chain.
+	Removed bool
+}
+
+func newEventMeta(log types.Log) EventMeta {
+	return EventMeta{
+		BlockNumber:     log.BlockNumber,
+		BlockHash:       Hash(log.BlockHash.Hex()),
+		TransactionHash: Hash(log.TxHash.Hex()),
+		LogIndex:        log.Index,
+		Removed:         log.Removed,
+	}
+}
+
+// TransferEvent is emitted when TFC is transferred, minted (From is zero address) or burned (To is zero address)
+type TransferEvent struct {
+	EventMeta
+	From  Address
+	To    Address
+	Value *big.Int
+}
+
+// TransferFilter restricts the Transfer events to subscribe. Empty fields match any address.
+type TransferFilter struct {
+	From []Address
+	To   []Address
+}
+
+// ApprovalEvent is emitted when the allowance of a spender for an owner is set
+type ApprovalEvent struct {
+	EventMeta
+	Owner   Address
+	Spender Address
+	Value   *big.Int
+}
+
+// ApprovalFilter restricts the Approval events to subscribe. Empty fields match any address.
+type ApprovalFilter struct {
+	Owner   []Address
+	Spender []Address
+}
+
+// RoleEvent is emitted when a role is granted to or revoked from an account
+type RoleEvent struct {
+	EventMeta
+	Role    Role
+	Account Address
+	Sender  Address // the account which grants or revokes the role
+}
+
+// RoleFilter restricts the RoleGranted or RoleRevoked events to subscribe. Empty fields match any role or address.
+type RoleFilter struct {
+	Roles    []Role
+	Accounts []Address
+	Senders  []Address
+}
+
+// PauseEvent is emitted when the token is paused or unpaused
+type PauseEvent struct {
+	EventMeta
+	Paused  bool
+	Account Address // the account which pauses or unpauses the token
+}
+
+// ClaimEvent is emitted when TFC is claimed with a signature in TFC Manager contract
+type ClaimEvent struct {
+	EventMeta
+	Recipient Address
+	Amount    *big.Int
+	Nonce     *big.Int
+	Signature []byte
+}
+
+func toCommonAddresses(addresses []Address) (result []common.Address, err error) {
+	for _, address := range addresses {
+		if !address.IsValid() {
+			return nil, InvalidAddressError
+		}
+		result = append(result, address.address())
+	}
+	return result, nil
+}
+
+/**
+Subscribes the Transfer events of TFC ERC20 contract matching the filter.
+
+The events are fed to eventCh until ctx is cancelled or there is an error, which will be fed to errCh.
+*/
+func (tfc *TFC) SubscribeTransfers(ctx context.Context, filter TransferFilter) (eventCh chan *TransferEvent, errCh chan error) {
+	eventCh = make(chan *TransferEvent)
+	errCh = make(chan error, 1)
+	from, err := toCommonAddresses(filter.From)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	to, err := toCommonAddresses(filter.To)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	sink := make(chan *token.TFCTokenTransfer)
+	sub, err := tfc.contract.WatchTransfer(&bind.WatchOpts{Context: ctx}, sink, from, to)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case err := <-sub.Err():
+				errCh <- err
+				return
+			case e := <-sink:
+				select {
+				case eventCh <- &TransferEvent{
+					EventMeta: newEventMeta(e.Raw),
+					From:      Address(e.From.Hex()),
+					To:        Address(e.To.Hex()),
+					Value:     e.Value,
+				}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return eventCh, errCh
+}
+
+/**
+Subscribes the Approval events of TFC ERC20 contract matching the filter.
+
+The events are fed to eventCh until ctx is cancelled or there is an error, which will be fed to errCh.
+*/
+func (tfc *TFC) SubscribeApprovals(ctx context.Context, filter ApprovalFilter) (eventCh chan *ApprovalEvent, errCh chan error) {
+	eventCh = make(chan *ApprovalEvent)
+	errCh = make(chan error, 1)
+	owner, err := toCommonAddresses(filter.Owner)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	spender, err := toCommonAddresses(filter.Spender)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	sink := make(chan *token.TFCTokenApproval)
+	sub, err := tfc.contract.WatchApproval(&bind.WatchOpts{Context: ctx}, sink, owner, spender)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case err := <-sub.Err():
+				errCh <- err
+				return
+			case e := <-sink:
+				select {
+				case eventCh <- &ApprovalEvent{
+					EventMeta: newEventMeta(e.Raw),
+					Owner:     Address(e.Owner.Hex()),
+					Spender:   Address(e.Spender.Hex()),
+					Value:     e.Value,
+				}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return eventCh, errCh
+}
+
+/**
+Resolves the role identifiers (bytes32) of the roles in the filter, as well as the mapping from identifiers to all roles.
+*/
+func (tfc *TFC) roleFilterRules(filter RoleFilter) (ids [][32]byte, roles map[[32]byte]Role, accounts []common.Address, senders []common.Address, err error) {
+	roles = make(map[[32]byte]Role)
+	for _, role := range Roles {
+		id, err := tfc.roleID(role)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		roles[id] = role
+	}
+	for _, role := range filter.Roles {
+		id, err := tfc.roleID(role)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+		ids = append(ids, id)
+	}
+	accounts, err = toCommonAddresses(filter.Accounts)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	senders, err = toCommonAddresses(filter.Senders)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return ids, roles, accounts, senders, nil
+}
+
+/**
+Subscribes the RoleGranted events of TFC ERC20 contract matching the filter.
+
+The events are fed to eventCh until ctx is cancelled or there is an error, which will be fed to errCh.
+*/
+func (tfc *TFC) SubscribeRoleGranted(ctx context.Context, filter RoleFilter) (eventCh chan *RoleEvent, errCh chan error) {
+	eventCh = make(chan *RoleEvent)
+	errCh = make(chan error, 1)
+	ids, roles, accounts, senders, err := tfc.roleFilterRules(filter)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	sink := make(chan *token.TFCTokenRoleGranted)
+	sub, err := tfc.contract.WatchRoleGranted(&bind.WatchOpts{Context: ctx}, sink, ids, accounts, senders)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case err := <-sub.Err():
+				errCh <- err
+				return
+			case e := <-sink:
+				role, ok := roles[e.Role]
+				if !ok {
+					role = -1
+				}
+				select {
+				case eventCh <- &RoleEvent{
+					EventMeta: newEventMeta(e.Raw),
+					Role:      role,
+					Account:   Address(e.Account.Hex()),
+					Sender:    Address(e.Sender.Hex()),
+				}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return eventCh, errCh
+}
+
+/**
+Subscribes the RoleRevoked events of TFC ERC20 contract matching the filter.
+
+The events are fed to eventCh until ctx is cancelled or there is an error, which will be fed to errCh.
+*/
+func (tfc *TFC) SubscribeRoleRevoked(ctx context.Context, filter RoleFilter) (eventCh chan *RoleEvent, errCh chan error) {
+	eventCh = make(chan *RoleEvent)
+	errCh = make(chan error, 1)
+	ids, roles, accounts, senders, err := tfc.roleFilterRules(filter)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	sink := make(chan *token.TFCTokenRoleRevoked)
+	sub, err := tfc.contract.WatchRoleRevoked(&bind.WatchOpts{Context: ctx}, sink, ids, accounts, senders)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	go func() {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case err := <-sub.Err():
+				errCh <- err
+				return
+			case e := <-sink:
+				role, ok := roles[e.Role]
+				if !ok {
+					role = -1
+				}
+				select {
+				case eventCh <- &RoleEvent{
+					EventMeta: newEventMeta(e.Raw),
+					Role:      role,
+					Account:   Address(e.Account.Hex()),
+					Sender:    Address(e.Sender.Hex()),
+				}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+		}
+	}()
+	return eventCh, errCh
+}
+
+/**
+Subscribes the Paused and Unpaused events of TFC ERC20 contract.
+
+The events are fed to eventCh until ctx is cancelled or there is an error, which will be fed to errCh.
+*/
+func (tfc *TFC) SubscribePauses(ctx context.Context) (eventCh chan *PauseEvent, errCh chan error) {
+	eventCh = make(chan *PauseEvent)
+	errCh = make(chan error, 1)
+	pausedSink := make(chan *token.TFCTokenPaused)
+	pausedSub, err := tfc.contract.WatchPaused(&bind.WatchOpts{Context: ctx}, pausedSink)
+	if err != nil {
+		errCh <- err
+		return eventCh, errCh
+	}
+	unpausedSink := make(chan *token.TFCTokenUnpaused)
+	unpausedSub, err := tfc.contract.WatchUnpaused(&bind.WatchOpts{Context: ctx}, unpausedSink)
+	if err != nil {
+		pausedSub.Unsubscribe()
+		errCh <- err
+		return eventCh, errCh
+	}
+	go func() {
+		defer pausedSub.Unsubscribe()
+		defer unpausedSub.Unsubscribe()
+		for {
+			var event *PauseEvent
+			select {
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			case err := <-pausedSub.Err():
+				errCh <- err
+				return
+			case err := <-unpausedSub.Err():
+				errCh <- err
+				return
+			case e := <-pausedSink:
+				event = &PauseEvent{EventMeta: newEventMeta(e.Raw), Paused: true, Account: Address(e.Account.Hex())}
+			case e := <-unpausedSink:
+				event = &PauseEvent{EventMeta: newEventMeta(e.Raw), Paused: false, Account: Address(e.Account.Hex())}
+			}
+			select {
+			case eventCh <- event:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+	return eventCh, errCh
+}
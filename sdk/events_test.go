@@ -0,0 +1,138 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestTFC_SubscribeTransfers(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), admin)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	eventCh, errCh := tfc.SubscribeTransfers(ctx, TransferFilter{To: []Address{user.Address()}})
+
+	// not matching the filter
+	err = tfc.MintSync(context.Background(), admin.Address(), big.NewInt(1), admin)
+	checkError(t, err)
+	err = tfc.MintSync(context.Background(), user.Address(), big.NewInt(100), admin)
+	checkError(t, err)
+
+	select {
+	case event := <-eventCh:
+		if event.From != Address(common.Address{}.Hex()) || event.To != user.Address() || event.Value.Cmp(big.NewInt(100)) != 0 {
+			t.Fatal("transfer event is incorrect", event)
+		}
+		if event.Removed || event.BlockNumber == 0 {
+			t.Fatal("transfer event meta is incorrect", event.EventMeta)
+		}
+		receipt, err := mockEth.Backend.TransactionReceipt(context.Background(), common.HexToHash(string(event.TransactionHash)))
+		checkError(t, err)
+		if receipt.BlockNumber.Uint64() != event.BlockNumber {
+			t.Fatal("block number does not match receipt")
+		}
+	case err := <-errCh:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("transfer event is not received")
+	}
+
+	cancel()
+	select {
+	case err := <-errCh:
+		if err != context.Canceled {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("subscription is not terminated by context")
+	}
+}
+
+func TestTFC_SubscribeRolesAndPauses(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), admin)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	grantedCh, grantedErrCh := tfc.SubscribeRoleGranted(ctx, RoleFilter{Roles: []Role{MinterRole}})
+	revokedCh, revokedErrCh := tfc.SubscribeRoleRevoked(ctx, RoleFilter{Accounts: []Address{user.Address()}})
+	pauseCh, pauseErrCh := tfc.SubscribePauses(ctx)
+
+	checkError(t, tfc.GrantRoleSync(context.Background(), PauserRole, user.Address(), admin))
+	checkError(t, tfc.GrantRoleSync(context.Background(), MinterRole, user.Address(), admin))
+	checkError(t, tfc.RevokeRoleSync(context.Background(), MinterRole, user.Address(), admin))
+	checkError(t, tfc.PauseSync(context.Background(), user))
+	checkError(t, tfc.UnpauseSync(context.Background(), admin))
+
+	select {
+	case event := <-grantedCh:
+		if event.Role != MinterRole || event.Account != user.Address() || event.Sender != admin.Address() {
+			t.Fatal("role granted event is incorrect", event)
+		}
+	case err := <-grantedErrCh:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("role granted event is not received")
+	}
+	select {
+	case event := <-revokedCh:
+		if event.Role != MinterRole || event.Account != user.Address() {
+			t.Fatal("role revoked event is incorrect", event)
+		}
+	case err := <-revokedErrCh:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("role revoked event is not received")
+	}
+	for _, expected := range []*PauseEvent{{Paused: true, Account: user.Address()}, {Paused: false, Account: admin.Address()}} {
+		select {
+		case event := <-pauseCh:
+			if event.Paused != expected.Paused || event.Account != expected.Account {
+				t.Fatal("pause event is incorrect", event)
+			}
+		case err := <-pauseErrCh:
+			t.Fatal(err)
+		case <-time.After(time.Second):
+			t.Fatal("pause event is not received")
+		}
+	}
+}
+
+func TestNewEventMeta_Removed(t *testing.T) {
+	log := types.Log{
+		BlockNumber: 10,
+		BlockHash:   common.HexToHash("0x01"),
+		TxHash:      common.HexToHash("0x02"),
+		Index:       3,
+		Removed:     true,
+	}
+	meta := newEventMeta(log)
+	if !meta.Removed || meta.BlockNumber != 10 || meta.LogIndex != 3 ||
+		meta.BlockHash != Hash(log.BlockHash.Hex()) || meta.TransactionHash != Hash(log.TxHash.Hex()) {
+		t.Fatal("event meta is incorrect", meta)
+	}
+}
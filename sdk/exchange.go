@@ -0,0 +1,458 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ExchangeState is the state of an ExchangeOrder
+type ExchangeState string
+
+const (
+	ExchangeQuoted           ExchangeState = "quoted"            // the fee is quoted, waiting for the deposit
+	ExchangeDepositSeen      ExchangeState = "deposit_seen"      // the deposit is received but not confirmed (or not enough)
+	ExchangeDepositConfirmed ExchangeState = "deposit_confirmed" // the deposit covering the quote is confirmed, the mint transaction is to be sent
+	ExchangeMintSent         ExchangeState = "mint_sent"         // the mint transaction is signed and sent
+	ExchangeMintConfirmed    ExchangeState = "mint_confirmed"    // the mint transaction is confirmed, final
+	ExchangeFailed           ExchangeState = "failed"            // the exchange cannot be completed, final
+)
+
+// IsFinal returns whether the state can no longer change
+func (state ExchangeState) IsFinal() bool {
+	return state == ExchangeMintConfirmed || state == ExchangeFailed
+}
+
+/**
+ExchangeOrder is a bridge exchange tracked by Exchange, which is persisted in the ExchangeStore on every change.
+*/
+type ExchangeOrder struct {
+	ID        string        `json:"id"`
+	Recipient Address       `json:"recipient"` // the address receiving TFC
+	Amount    *big.Int      `json:"amount"`    // the amount of TFC to mint
+	State     ExchangeState `json:"state"`
+
+	// the deposit address of the order, see DepositAddresses
+	DepositIndex   uint32  `json:"depositIndex"`
+	DepositAddress Address `json:"depositAddress"`
+
+	// the quote signed by the bridge account (see EstimateTFCExchangeFee), by which the mint transaction is sent
+	Quote                  *Quote    `json:"quote"`
+	RequiredTransferAmount *big.Int  `json:"requiredTransferAmount"` // the RequiredTransferAmount of the quote
+	QuoteExpiry            time.Time `json:"quoteExpiry"`            // zero if the quote never expires
+
+	DepositAmount *big.Int `json:"depositAmount,omitempty"`
+
+	// the signed mint transaction, persisted before it is sent so that it can be resent after restart
+	MintTransaction     hexutil.Bytes `json:"mintTransaction,omitempty"`
+	MintTransactionHash Hash          `json:"mintTransactionHash,omitempty"`
+
+	Error     string    `json:"error,omitempty"` // the reason of failure, or the last error of a retried step
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+/**
+ExchangeHook is called after the order is changed and persisted, with the state before the change.
+A re-quote is notified with the same state before and after.
+*/
+type ExchangeHook func(order ExchangeOrder, previous ExchangeState)
+
+// ExchangeConfig configures an Exchange
+type ExchangeConfig struct {
+	Bridge   *Account          // the bridge account minting TFC, which must have MINTER_ROLE
+	Deposits *DepositAddresses // derives the deposit address of each order
+	Store    ExchangeStore     // persists the orders, a MemoryExchangeStore if nil
+
+	MinGas             uint64        // see EstimateTFCExchangeFee
+	TransactionFeeRate float64       // see EstimateTFCExchangeFee
+	QuoteTTL           time.Duration // the quote is renewed if it expires before any deposit is seen, 0 means never expire
+
+	DepositConfirmationRequirement int
+	MintConfirmationRequirement    int
+	PollInterval                   time.Duration // the interval of Run
+}
+
+/**
+Exchange orchestrates bridge exchanges end to end, which are driven by a state machine of each order:
+quoted -> deposit seen -> deposit confirmed -> mint sent -> mint confirmed / failed.
+
+Every change of an order is persisted in the ExchangeStore before it takes effect,
+and the signed mint transaction is persisted before it is sent,
+so an Exchange created with the same store resumes the orders after a crash without minting twice.
+*/
+type Exchange struct {
+	tfc    *TFC
+	config ExchangeConfig
+
+	stepLock sync.Mutex // serializes steps, so that an order is never advanced concurrently
+
+	lock   sync.Mutex
+	orders map[string]*ExchangeOrder
+	hooks  []ExchangeHook
+}
+
+/**
+Creates an Exchange of the TFC, which resumes the orders persisted in config.Store.
+The deposit addresses of the persisted orders are restored in config.Deposits.
+*/
+func (tfc *TFC) NewExchange(config ExchangeConfig) (exchange *Exchange, err error) {
+	if config.Bridge == nil || config.Deposits == nil {
+		return nil, InvalidExchangeConfigErr
+	}
+	if config.Store == nil {
+		config.Store = NewMemoryExchangeStore()
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = time.Second
+	}
+	orders, err := config.Store.Load()
+	if err != nil {
+		return nil, err
+	}
+	exchange = &Exchange{
+		tfc:    tfc,
+		config: config,
+		orders: make(map[string]*ExchangeOrder),
+	}
+	for i := range orders {
+		order := orders[i]
+		err := config.Deposits.RestoreOrder(DepositOrder{
+			ID:        order.ID,
+			Recipient: order.Recipient,
+			Index:     order.DepositIndex,
+			Address:   order.DepositAddress,
+			Settled:   order.State == ExchangeMintConfirmed,
+		})
+		if err != nil && err != DuplicateDepositOrderErr {
+			return nil, err
+		}
+		exchange.orders[order.ID] = &order
+	}
+	return exchange, nil
+}
+
+/**
+Registers the hook called on every change of orders.
+*/
+func (e *Exchange) OnStateChange(hook ExchangeHook) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	e.hooks = append(e.hooks, hook)
+}
+
+/**
+Create an order exchanging amount of TFC to recipient, which is quoted with a dedicated deposit address.
+The user should deposit RequiredTransferAmount of ETH to DepositAddress before QuoteExpiry.
+*/
+func (e *Exchange) Quote(ctx context.Context, id string, recipient Address, amount *big.Int) (order ExchangeOrder, err error) {
+	if amount == nil || amount.Sign() < 0 {
+		return ExchangeOrder{}, InvalidAmountErr
+	}
+	e.lock.Lock()
+	_, exists := e.orders[id]
+	e.lock.Unlock()
+	if exists {
+		return ExchangeOrder{}, DuplicateDepositOrderErr
+	}
+	order = ExchangeOrder{
+		ID:        id,
+		Recipient: recipient,
+		Amount:    amount,
+		State:     ExchangeQuoted,
+	}
+	if err := e.quote(ctx, &order); err != nil {
+		return ExchangeOrder{}, err
+	}
+	depositOrder, err := e.config.Deposits.NewOrder(id, recipient)
+	if err != nil {
+		return ExchangeOrder{}, err
+	}
+	order.DepositIndex = depositOrder.Index
+	order.DepositAddress = depositOrder.Address
+	if err := e.update(order, ""); err != nil {
+		return ExchangeOrder{}, err
+	}
+	return order, nil
+}
+
+// quote estimates the fee of the order, and signs it as the Quote of the order
+func (e *Exchange) quote(ctx context.Context, order *ExchangeOrder) error {
+	quote, err := e.tfc.EstimateTFCExchangeFee(ctx, order.Recipient, order.Amount, e.config.Bridge, e.config.MinGas, e.config.TransactionFeeRate)
+	if err != nil {
+		return err
+	}
+	order.Quote = quote
+	order.RequiredTransferAmount = quote.RequiredTransferAmount
+	order.QuoteExpiry = time.Time{}
+	if e.config.QuoteTTL > 0 {
+		order.QuoteExpiry = time.Now().Add(e.config.QuoteTTL)
+	}
+	return nil
+}
+
+/**
+Returns the order with id.
+*/
+func (e *Exchange) Order(id string) (order ExchangeOrder, err error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	o, ok := e.orders[id]
+	if !ok {
+		return ExchangeOrder{}, UnknownDepositOrderErr
+	}
+	return *o, nil
+}
+
+/**
+Returns all orders sorted by the index of deposit address, i.e. the creation order.
+*/
+func (e *Exchange) Orders() (orders []ExchangeOrder) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for _, o := range e.orders {
+		orders = append(orders, *o)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].DepositIndex < orders[j].DepositIndex
+	})
+	return orders
+}
+
+// update persists the order and then takes it in memory, and notifies the hooks if previous is not empty
+func (e *Exchange) update(order ExchangeOrder, previous ExchangeState) error {
+	order.UpdatedAt = time.Now()
+	if err := e.config.Store.Save(order); err != nil {
+		return err
+	}
+	e.lock.Lock()
+	e.orders[order.ID] = &order
+	e.lock.Unlock()
+	if previous != "" {
+		e.notify(order, previous)
+	}
+	return nil
+}
+
+func (e *Exchange) notify(order ExchangeOrder, previous ExchangeState) {
+	e.lock.Lock()
+	hooks := e.hooks
+	e.lock.Unlock()
+	for _, hook := range hooks {
+		hook(order, previous)
+	}
+}
+
+/**
+Run advances the orders every PollInterval until ctx is done.
+Errors of steps are retried in the next round, which can be observed via the Error of orders.
+*/
+func (e *Exchange) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		_ = e.Step(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+/**
+Step advances each unfinished order as far as possible at the moment.
+The returned err is the first error of the orders, which are retried in the next Step.
+*/
+func (e *Exchange) Step(ctx context.Context) (err error) {
+	e.stepLock.Lock()
+	defer e.stepLock.Unlock()
+	for _, order := range e.Orders() {
+		if order.State.IsFinal() {
+			continue
+		}
+		if stepErr := e.step(ctx, order); stepErr != nil && err == nil {
+			err = stepErr
+		}
+	}
+	return err
+}
+
+func (e *Exchange) step(ctx context.Context, order ExchangeOrder) (err error) {
+	for !order.State.IsFinal() {
+		previous := order.State
+		switch order.State {
+		case ExchangeQuoted, ExchangeDepositSeen:
+			err = e.checkDeposit(ctx, &order)
+		case ExchangeDepositConfirmed:
+			err = e.sendMint(ctx, &order)
+		case ExchangeMintSent:
+			err = e.checkMint(ctx, &order)
+		}
+		if err != nil {
+			// keep the error of the retried step for inspection
+			order.Error = err.Error()
+			if updateErr := e.update(order, ""); updateErr != nil {
+				return updateErr
+			}
+			return err
+		}
+		if order.State == previous {
+			return nil
+		}
+	}
+	return nil
+}
+
+// checkDeposit moves the order to deposit seen or deposit confirmed, or re-quotes it if the quote expires before any deposit is seen.
+// A seen deposit is made against the quote at the moment, which is honored even if it expires before the deposit is confirmed.
+func (e *Exchange) checkDeposit(ctx context.Context, order *ExchangeOrder) error {
+	confirmed, latest, err := e.config.Deposits.depositBalance(ctx, order.DepositAddress, e.config.DepositConfirmationRequirement)
+	if err != nil {
+		return err
+	}
+	previous := order.State
+	if latest.Sign() > 0 && order.State == ExchangeQuoted {
+		order.State = ExchangeDepositSeen
+		order.DepositAmount = latest
+		order.Error = ""
+		return e.update(*order, previous)
+	}
+	if confirmed.Cmp(order.RequiredTransferAmount) >= 0 {
+		order.State = ExchangeDepositConfirmed
+		order.DepositAmount = confirmed
+		order.Error = ""
+		return e.update(*order, previous)
+	}
+	if order.State == ExchangeQuoted && !order.QuoteExpiry.IsZero() && time.Now().After(order.QuoteExpiry) {
+		if err := e.quote(ctx, order); err != nil {
+			return err
+		}
+		order.Error = ""
+		return e.update(*order, previous)
+	}
+	return nil
+}
+
+// sendMint signs the mint transaction of the quote, persists it, and then sends it.
+// The signature of the quote is verified, but not its expiry, since the quote is honored once the deposit is seen (see checkDeposit).
+func (e *Exchange) sendMint(ctx context.Context, order *ExchangeOrder) error {
+	quote := order.Quote
+	err := e.tfc.verifyQuoteSignature(ctx, quote, e.config.Bridge.Address())
+	if err == nil && (quote.Recipient.address() != order.Recipient.address() || quote.Amount.Cmp(order.Amount) != 0 ||
+		quote.RequiredTransferAmount.Cmp(order.RequiredTransferAmount) != 0) {
+		err = InvalidQuoteErr
+	}
+	if err == nil && order.DepositAmount.Cmp(quote.RequiredTransferAmount) < 0 {
+		err = DepositBelowQuoteErr
+	}
+	if err == InvalidQuoteErr || err == DepositBelowQuoteErr {
+		previous := order.State
+		order.State = ExchangeFailed
+		order.Error = err.Error()
+		return e.update(*order, previous)
+	} else if err != nil {
+		return err
+	}
+	sent, persisted := *order, false
+	_, err = e.tfc.sendMintTx(ctx, quote.Recipient, quote.Amount, e.config.Bridge, order.DepositAmount, quote.EstimatedGas, quote.GasPrice, quote.TransactionFeeRate, func(tx *types.Transaction) error {
+		raw, err := tx.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		sent.State = ExchangeMintSent
+		sent.MintTransaction = raw
+		sent.MintTransactionHash = Hash(tx.Hash().Hex())
+		sent.Error = ""
+		if err := e.update(sent, ""); err != nil {
+			return err
+		}
+		persisted = true
+		return e.tfc.backend.SendTransaction(ctx, tx)
+	})
+	if err == InsufficientTransactionFeeErr || err == InsufficientGasErr {
+		previous := order.State
+		order.State = ExchangeFailed
+		order.Error = err.Error()
+		return e.update(*order, previous)
+	}
+	if persisted {
+		// the mint transaction may have been sent even if err is not nil, which is resolved by checkMint
+		*order = sent
+		e.notify(sent, ExchangeDepositConfirmed)
+	}
+	return err
+}
+
+// checkMint moves the order to mint confirmed or failed once the mint transaction is mined,
+// and resends the persisted mint transaction if the node does not know it (e.g. the process crashed before sending it)
+func (e *Exchange) checkMint(ctx context.Context, order *ExchangeOrder) error {
+	txHash := common.HexToHash(string(order.MintTransactionHash))
+	receipt, err := e.tfc.backend.TransactionReceipt(ctx, txHash)
+	if err == ethereum.NotFound {
+		return e.resendMint(ctx, order)
+	} else if err != nil {
+		return err
+	}
+	confirmations, err := e.tfc.getConfirmationCount(ctx, receipt.BlockNumber, receipt.BlockHash)
+	if err != nil {
+		return err
+	}
+	if confirmations < e.config.MintConfirmationRequirement {
+		// not confirmed yet, or reorged out and waiting to be mined again
+		return nil
+	}
+	previous := order.State
+	if err := e.tfc.checkReceipt(ctx, receipt); err != nil {
+		order.State = ExchangeFailed
+		order.Error = err.Error()
+		return e.update(*order, previous)
+	}
+	if err := e.config.Deposits.Settle(order.ID); err != nil {
+		return err
+	}
+	order.State = ExchangeMintConfirmed
+	order.Error = ""
+	return e.update(*order, previous)
+}
+
+func (e *Exchange) resendMint(ctx context.Context, order *ExchangeOrder) error {
+	txHash := common.HexToHash(string(order.MintTransactionHash))
+	if _, _, err := e.tfc.backend.TransactionByHash(ctx, txHash); err == nil {
+		// pending
+		return nil
+	} else if err != ethereum.NotFound {
+		return err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(order.MintTransaction); err != nil {
+		return err
+	}
+	sendErr := e.tfc.backend.SendTransaction(ctx, tx)
+	if sendErr == nil {
+		return nil
+	}
+	// the transaction cannot be sent, it is safe to mint again if its nonce has been used by another transaction
+	nonce, err := e.tfc.backend.NonceAt(ctx, e.config.Bridge.address, nil)
+	if err != nil {
+		return err
+	}
+	if nonce <= tx.Nonce() {
+		return sendErr
+	}
+	if _, _, err := e.tfc.backend.TransactionByHash(ctx, txHash); err != ethereum.NotFound {
+		// mined in the meantime
+		return err
+	}
+	previous := order.State
+	order.State = ExchangeDepositConfirmed
+	order.MintTransaction = nil
+	order.MintTransactionHash = ""
+	order.Error = sendErr.Error()
+	return e.update(*order, previous)
+}
@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"encoding/json"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+	"sync"
+)
+
+/**
+ExchangeStore persists the orders of Exchange.
+*/
+type ExchangeStore interface {
+	// Save creates or overwrites the order, which must be durable when it returns
+	Save(order ExchangeOrder) error
+	// Load returns all saved orders
+	Load() ([]ExchangeOrder, error)
+}
+
+/**
+MemoryExchangeStore keeps the orders in memory, which are lost when the process exits.
+*/
+type MemoryExchangeStore struct {
+	lock   sync.Mutex
+	orders map[string]ExchangeOrder
+}
+
+func NewMemoryExchangeStore() *MemoryExchangeStore {
+	return &MemoryExchangeStore{orders: make(map[string]ExchangeOrder)}
+}
+
+func (s *MemoryExchangeStore) Save(order ExchangeOrder) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.orders[order.ID] = order
+	return nil
+}
+
+func (s *MemoryExchangeStore) Load() ([]ExchangeOrder, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	orders := make([]ExchangeOrder, 0, len(s.orders))
+	for _, order := range s.orders {
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// key prefix of orders in LevelDBExchangeStore
+var exchangeOrderPrefix = []byte("exchange-order-")
+
+/**
+LevelDBExchangeStore persists the orders as JSON in a LevelDB database on disk, and every write is synced before it returns.
+The database can only be opened by one process at a time.
+*/
+type LevelDBExchangeStore struct {
+	db *leveldb.DB
+}
+
+/**
+Open (or create) the LevelDBExchangeStore in the directory.
+*/
+func OpenLevelDBExchangeStore(dir string) (store *LevelDBExchangeStore, err error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBExchangeStore{db: db}, nil
+}
+
+func (s *LevelDBExchangeStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *LevelDBExchangeStore) Save(order ExchangeOrder) error {
+	value, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+	key := append(append([]byte{}, exchangeOrderPrefix...), order.ID...)
+	return s.db.Put(key, value, syncWrite)
+}
+
+func (s *LevelDBExchangeStore) Load() ([]ExchangeOrder, error) {
+	it := s.db.NewIterator(util.BytesPrefix(exchangeOrderPrefix), nil)
+	defer it.Release()
+	var orders []ExchangeOrder
+	for it.Next() {
+		var order ExchangeOrder
+		if err := json.Unmarshal(it.Value(), &order); err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, it.Error()
+}
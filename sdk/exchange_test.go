@@ -0,0 +1,323 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/core/types"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"testing"
+	"time"
+)
+
+// crashingBackend does not send transactions while crashed, as if the process crashed right before sending them
+type crashingBackend struct {
+	*MockBackend
+	crashed bool
+}
+
+func (b *crashingBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if b.crashed {
+		return errors.New("crashed")
+	}
+	return b.MockBackend.SendTransaction(ctx, tx)
+}
+
+type exchangeFixture struct {
+	mockEth    *MockEthereum
+	tfcAddress Address
+	xpub       string
+	dir        string
+}
+
+func newExchangeFixture(t *testing.T) *exchangeFixture {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	tfcAddress, err := NewSDKWithBackend(mockEth.Backend).DeployTFCSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+	mnemonic, err := NewMnemonic()
+	checkError(t, err)
+	xpub, err := (&SDK{}).DeriveAccountXPub(mnemonic)
+	checkError(t, err)
+	dir, err := ioutil.TempDir("", "exchange")
+	checkError(t, err)
+	return &exchangeFixture{mockEth: mockEth, tfcAddress: tfcAddress, xpub: xpub, dir: dir}
+}
+
+func (f *exchangeFixture) close() {
+	f.mockEth.Stop()
+	os.RemoveAll(f.dir)
+}
+
+// start creates an Exchange with the persisted store, as if the process is (re)started
+func (f *exchangeFixture) start(t *testing.T, backend Backend) (exchange *Exchange, store *LevelDBExchangeStore) {
+	sdk := NewSDKWithBackend(backend)
+	tfc, err := sdk.TFC(f.tfcAddress)
+	checkError(t, err)
+	deposits, err := sdk.DepositAddresses(f.xpub)
+	checkError(t, err)
+	store, err = OpenLevelDBExchangeStore(f.dir)
+	checkError(t, err)
+	exchange, err = tfc.NewExchange(ExchangeConfig{
+		Bridge:                         PredefinedAccounts[0],
+		Deposits:                       deposits,
+		Store:                          store,
+		TransactionFeeRate:             0.1,
+		DepositConfirmationRequirement: 1,
+	})
+	checkError(t, err)
+	return exchange, store
+}
+
+func (f *exchangeFixture) balanceOf(t *testing.T, address Address) *big.Int {
+	tfc, err := NewSDKWithBackend(f.mockEth.Backend).TFC(f.tfcAddress)
+	checkError(t, err)
+	balance, err := tfc.BalanceOf(address)
+	checkError(t, err)
+	return balance
+}
+
+// stepUntil steps the exchange until the order reaches the state
+func stepUntil(t *testing.T, exchange *Exchange, id string, state ExchangeState) ExchangeOrder {
+	for i := 0; i < 100; i++ {
+		_ = exchange.Step(context.Background())
+		order, err := exchange.Order(id)
+		checkError(t, err)
+		if order.State == state {
+			return order
+		}
+		if order.State.IsFinal() {
+			t.Fatal("order is", order.State, order.Error)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	t.Fatal("order does not reach", state)
+	return ExchangeOrder{}
+}
+
+// quoteAndDeposit creates an order and deposits the required amount with one confirmation
+func quoteAndDeposit(t *testing.T, f *exchangeFixture, exchange *Exchange, id string, recipient Address) ExchangeOrder {
+	order, err := exchange.Quote(context.Background(), id, recipient, big.NewInt(100))
+	checkError(t, err)
+	depositTo(t, f.mockEth.Backend, PredefinedAccounts[1], order.DepositAddress, order.RequiredTransferAmount)
+	// one more block to confirm the deposit
+	depositTo(t, f.mockEth.Backend, PredefinedAccounts[1], PredefinedAccounts[2].Address(), big.NewInt(1))
+	return order
+}
+
+func TestExchange(t *testing.T) {
+	f := newExchangeFixture(t)
+	defer f.close()
+	exchange, store := f.start(t, f.mockEth.Backend)
+	defer store.Close()
+	var transitions []string
+	exchange.OnStateChange(func(order ExchangeOrder, previous ExchangeState) {
+		transitions = append(transitions, string(previous)+"->"+string(order.State))
+	})
+
+	recipient := PredefinedAccounts[3].Address()
+	order, err := exchange.Quote(context.Background(), "order-1", recipient, big.NewInt(100))
+	checkError(t, err)
+	if order.State != ExchangeQuoted || order.RequiredTransferAmount.Sign() <= 0 {
+		t.Fatal("order is not quoted")
+	}
+	checkError(t, exchange.Step(context.Background()))
+	if order, _ := exchange.Order("order-1"); order.State != ExchangeQuoted {
+		t.Fatal("order should wait for deposit, got", order.State)
+	}
+
+	depositTo(t, f.mockEth.Backend, PredefinedAccounts[1], order.DepositAddress, order.RequiredTransferAmount)
+	checkError(t, exchange.Step(context.Background()))
+	if order, _ := exchange.Order("order-1"); order.State != ExchangeDepositSeen {
+		t.Fatal("deposit should be seen but not confirmed, got", order.State)
+	}
+	depositTo(t, f.mockEth.Backend, PredefinedAccounts[1], PredefinedAccounts[2].Address(), big.NewInt(1))
+	order = stepUntil(t, exchange, "order-1", ExchangeMintConfirmed)
+	if order.DepositAmount.Cmp(order.RequiredTransferAmount) != 0 || order.MintTransactionHash == "" {
+		t.Fatal("order is incorrect", order)
+	}
+	if f.balanceOf(t, recipient).Cmp(big.NewInt(100)) != 0 {
+		t.Fatal("recipient should be minted")
+	}
+	expected := []string{
+		"quoted->deposit_seen",
+		"deposit_seen->deposit_confirmed",
+		"deposit_confirmed->mint_sent",
+		"mint_sent->mint_confirmed",
+	}
+	if len(transitions) != len(expected) {
+		t.Fatal("transitions are incorrect, got", transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Fatal("transitions are incorrect, got", transitions)
+		}
+	}
+}
+
+func TestExchange_requote(t *testing.T) {
+	f := newExchangeFixture(t)
+	defer f.close()
+	sdk := NewSDKWithBackend(f.mockEth.Backend)
+	tfc, err := sdk.TFC(f.tfcAddress)
+	checkError(t, err)
+	deposits, err := sdk.DepositAddresses(f.xpub)
+	checkError(t, err)
+	exchange, err := tfc.NewExchange(ExchangeConfig{
+		Bridge:   PredefinedAccounts[0],
+		Deposits: deposits,
+		QuoteTTL: time.Millisecond,
+	})
+	checkError(t, err)
+	requoted := 0
+	exchange.OnStateChange(func(order ExchangeOrder, previous ExchangeState) {
+		if previous == ExchangeQuoted && order.State == ExchangeQuoted {
+			requoted++
+		}
+	})
+
+	order, err := exchange.Quote(context.Background(), "order-1", PredefinedAccounts[3].Address(), big.NewInt(100))
+	checkError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	checkError(t, exchange.Step(context.Background()))
+	requotedOrder, err := exchange.Order("order-1")
+	checkError(t, err)
+	if requoted != 1 || !requotedOrder.QuoteExpiry.After(order.QuoteExpiry) {
+		t.Fatal("expired quote should be renewed")
+	}
+
+	// the quote of a seen deposit is honored after it expires
+	required := requotedOrder.RequiredTransferAmount
+	half := new(big.Int).Div(required, big.NewInt(2))
+	depositTo(t, f.mockEth.Backend, PredefinedAccounts[1], order.DepositAddress, half)
+	checkError(t, exchange.Step(context.Background()))
+	time.Sleep(5 * time.Millisecond)
+	checkError(t, exchange.Step(context.Background()))
+	seenOrder, err := exchange.Order("order-1")
+	checkError(t, err)
+	if seenOrder.State != ExchangeDepositSeen || !seenOrder.QuoteExpiry.Equal(requotedOrder.QuoteExpiry) {
+		t.Fatal("seen deposit should not be re-quoted", seenOrder)
+	}
+	depositTo(t, f.mockEth.Backend, PredefinedAccounts[1], order.DepositAddress, new(big.Int).Sub(required, half))
+	seenOrder = stepUntil(t, exchange, "order-1", ExchangeMintConfirmed)
+	if seenOrder.RequiredTransferAmount.Cmp(required) != 0 || seenOrder.DepositAmount.Cmp(required) != 0 {
+		t.Fatal("deposit should be confirmed against the seen quote", seenOrder)
+	}
+}
+
+func TestExchange_resume(t *testing.T) {
+	f := newExchangeFixture(t)
+	defer f.close()
+
+	crashing := &crashingBackend{MockBackend: f.mockEth.Backend}
+	exchange, store := f.start(t, crashing)
+
+	// crash after the mint transaction is sent, which is mined while the exchange is down
+	quoteAndDeposit(t, f, exchange, "sent", PredefinedAccounts[4].Address())
+	checkError(t, exchange.Step(context.Background()))
+	if order, _ := exchange.Order("sent"); order.State != ExchangeMintSent {
+		t.Fatal("mint transaction should be sent, got", order.State)
+	}
+
+	// crash after the mint transaction is persisted but before it is sent
+	quoteAndDeposit(t, f, exchange, "unsent", PredefinedAccounts[3].Address())
+	crashing.crashed = true
+	_ = exchange.Step(context.Background())
+	unsent, err := exchange.Order("unsent")
+	checkError(t, err)
+	if unsent.State != ExchangeMintSent {
+		t.Fatal("mint transaction should be persisted, got", unsent.State)
+	}
+	checkError(t, store.Close())
+
+	// the unsent mint transaction is resent, and the sent one is not sent again
+	exchange, store = f.start(t, f.mockEth.Backend)
+	defer store.Close()
+	resumed, err := exchange.Order("unsent")
+	checkError(t, err)
+	if resumed.State != ExchangeMintSent {
+		t.Fatal("order should be resumed, got", resumed.State)
+	}
+	resent := stepUntil(t, exchange, "unsent", ExchangeMintConfirmed)
+	if resent.MintTransactionHash != unsent.MintTransactionHash {
+		t.Fatal("the persisted mint transaction should be resent")
+	}
+	stepUntil(t, exchange, "sent", ExchangeMintConfirmed)
+	if f.balanceOf(t, PredefinedAccounts[3].Address()).Cmp(big.NewInt(100)) != 0 ||
+		f.balanceOf(t, PredefinedAccounts[4].Address()).Cmp(big.NewInt(100)) != 0 {
+		t.Fatal("recipients should be minted exactly once")
+	}
+	// the deposit addresses of confirmed orders are restored as settled
+	order, err := exchange.Order("sent")
+	checkError(t, err)
+	depositOrder, err := exchange.config.Deposits.OrderOf(order.DepositAddress)
+	checkError(t, err)
+	if !depositOrder.Settled {
+		t.Fatal("deposit order should be settled")
+	}
+}
+
+func TestExchange_resume_nonceReused(t *testing.T) {
+	f := newExchangeFixture(t)
+	defer f.close()
+
+	crashing := &crashingBackend{MockBackend: f.mockEth.Backend}
+	exchange, store := f.start(t, crashing)
+	quoteAndDeposit(t, f, exchange, "order-1", PredefinedAccounts[3].Address())
+	crashing.crashed = true
+	_ = exchange.Step(context.Background())
+	checkError(t, store.Close())
+
+	// the nonce of the persisted mint transaction is used by another transaction before restart
+	sdk := NewSDKWithBackend(f.mockEth.Backend)
+	tfc, err := sdk.TFC(f.tfcAddress)
+	checkError(t, err)
+	checkError(t, tfc.MintSync(context.Background(), PredefinedAccounts[5].Address(), big.NewInt(1), PredefinedAccounts[0]))
+
+	// the mint is sent again since the persisted one can never be mined
+	exchange, store = f.start(t, f.mockEth.Backend)
+	defer store.Close()
+	stepUntil(t, exchange, "order-1", ExchangeMintConfirmed)
+	if f.balanceOf(t, PredefinedAccounts[3].Address()).Cmp(big.NewInt(100)) != 0 {
+		t.Fatal("recipient should be minted exactly once")
+	}
+}
+
+func TestExchange_quote(t *testing.T) {
+	f := newExchangeFixture(t)
+	defer f.close()
+	validity := QuoteValidity
+	defer func() { QuoteValidity = validity }()
+	// the signed quotes expire immediately, which are honored once the deposit is seen
+	QuoteValidity = -time.Second
+
+	exchange, store := f.start(t, f.mockEth.Backend)
+	order := quoteAndDeposit(t, f, exchange, "expired", PredefinedAccounts[3].Address())
+	if err := exchange.tfc.VerifyQuote(context.Background(), order.Quote, PredefinedAccounts[0].Address()); err != QuoteExpiredErr {
+		t.Fatal("expect QuoteExpiredErr, got", err)
+	}
+	stepUntil(t, exchange, "expired", ExchangeMintConfirmed)
+
+	// the order fails if its quote is altered in the store
+	order = quoteAndDeposit(t, f, exchange, "altered", PredefinedAccounts[4].Address())
+	altered := *order.Quote
+	altered.Amount = big.NewInt(1000)
+	order.Quote = &altered
+	order.Amount = altered.Amount
+	checkError(t, store.Save(order))
+	checkError(t, store.Close())
+	exchange, store = f.start(t, f.mockEth.Backend)
+	defer store.Close()
+	for i := 0; i < 10; i++ {
+		_ = exchange.Step(context.Background())
+	}
+	order, err := exchange.Order("altered")
+	checkError(t, err)
+	if order.State != ExchangeFailed || order.Error != InvalidQuoteErr.Error() {
+		t.Fatal("order with altered quote should fail, got", order.State, order.Error)
+	}
+	if f.balanceOf(t, PredefinedAccounts[4].Address()).Sign() != 0 {
+		t.Fatal("altered quote should not be minted")
+	}
+}
@@ -0,0 +1,166 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+// BaseFeeMultiplier is the number of times of the current base fee covered by the max fee per gas of dynamic fee transactions
+var BaseFeeMultiplier = 2
+
+// error code of JSON-RPC when the method is not supported by the node
+const methodNotFoundCode = -32601
+
+/**
+Fees is the fee setting of a transaction.
+If GasPrice is set, the transaction is a legacy one,
+otherwise it is a dynamic fee (EIP-1559) transaction with GasFeeCap (maxFeePerGas) and GasTipCap (maxPriorityFeePerGas).
+*/
+type Fees struct {
+	GasPrice  *big.Int
+	GasFeeCap *big.Int
+	GasTipCap *big.Int
+}
+
+// IsDynamic returns true if the fees are of a dynamic fee (EIP-1559) transaction
+func (fees *Fees) IsDynamic() bool {
+	return fees.GasPrice == nil
+}
+
+/**
+Returns the worst-case price paid for each gas, i.e. the gas price of legacy transactions,
+or the max fee per gas of dynamic fee transactions.
+*/
+func (fees *Fees) MaxGasPrice() *big.Int {
+	if fees.IsDynamic() {
+		return fees.GasFeeCap
+	}
+	return fees.GasPrice
+}
+
+/**
+Returns a copy of the fees with the worst-case price per gas capped at maxGasPrice.
+The priority fee of dynamic fee transactions is lowered as well if it exceeds maxGasPrice.
+*/
+func (fees *Fees) withMaxGasPrice(maxGasPrice *big.Int) *Fees {
+	if !fees.IsDynamic() {
+		return &Fees{GasPrice: maxGasPrice}
+	}
+	tip := fees.GasTipCap
+	if tip.Cmp(maxGasPrice) > 0 {
+		tip = maxGasPrice
+	}
+	return &Fees{GasFeeCap: maxGasPrice, GasTipCap: tip}
+}
+
+// apply sets the fee fields of the transactor, the fields of the other transaction type are cleared
+func (fees *Fees) apply(auth *bind.TransactOpts) {
+	auth.GasPrice = fees.GasPrice
+	auth.GasFeeCap = fees.GasFeeCap
+	auth.GasTipCap = fees.GasTipCap
+}
+
+// applyCall sets the fee fields of the call message, e.g. to estimate gas
+func (fees *Fees) applyCall(msg *ethereum.CallMsg) {
+	msg.GasPrice = fees.GasPrice
+	msg.GasFeeCap = fees.GasFeeCap
+	msg.GasTipCap = fees.GasTipCap
+}
+
+// feesOf returns the fees of the transaction
+func feesOf(tx *types.Transaction) *Fees {
+	if tx.Type() == types.LegacyTxType {
+		return &Fees{GasPrice: tx.GasPrice()}
+	}
+	return &Fees{GasFeeCap: tx.GasFeeCap(), GasTipCap: tx.GasTipCap()}
+}
+
+/**
+FeeStrategy decides the fees of the transactions sent by the SDK.
+*/
+type FeeStrategy interface {
+	// Fees returns the fees of the next transaction sent via backend
+	Fees(ctx context.Context, backend Backend) (fees *Fees, err error)
+}
+
+var (
+	// LegacyFeeStrategy always sends legacy transactions with the gas price suggested by the node
+	LegacyFeeStrategy FeeStrategy = legacyFeeStrategy{}
+	// DynamicFeeStrategy always sends dynamic fee (EIP-1559) transactions with the priority fee suggested by the node,
+	// and a max fee per gas covering BaseFeeMultiplier times of the current base fee.
+	// DynamicFeeNotSupportedErr is returned if the chain does not support EIP-1559.
+	DynamicFeeStrategy FeeStrategy = dynamicFeeStrategy{}
+	// AutoFeeStrategy sends dynamic fee transactions if the chain supports EIP-1559, otherwise legacy transactions
+	AutoFeeStrategy FeeStrategy = autoFeeStrategy{}
+)
+
+type legacyFeeStrategy struct{}
+
+func (legacyFeeStrategy) Fees(ctx context.Context, backend Backend) (fees *Fees, err error) {
+	gasPrice, err := backend.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Fees{GasPrice: gasPrice}, nil
+}
+
+type dynamicFeeStrategy struct{}
+
+func (dynamicFeeStrategy) Fees(ctx context.Context, backend Backend) (fees *Fees, err error) {
+	head, err := backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if head.BaseFee == nil {
+		return nil, DynamicFeeNotSupportedErr
+	}
+	tip, err := backend.SuggestGasTipCap(ctx)
+	if isMethodNotFound(err) {
+		return nil, DynamicFeeNotSupportedErr
+	} else if err != nil {
+		return nil, err
+	}
+	feeCap := new(big.Int).Mul(head.BaseFee, big.NewInt(int64(BaseFeeMultiplier)))
+	feeCap.Add(feeCap, tip)
+	return &Fees{GasFeeCap: feeCap, GasTipCap: tip}, nil
+}
+
+type autoFeeStrategy struct{}
+
+func (autoFeeStrategy) Fees(ctx context.Context, backend Backend) (fees *Fees, err error) {
+	fees, err = DynamicFeeStrategy.Fees(ctx, backend)
+	if err == DynamicFeeNotSupportedErr {
+		return LegacyFeeStrategy.Fees(ctx, backend)
+	}
+	return fees, err
+}
+
+// isMethodNotFound returns true if the error is returned by a node which does not support the JSON-RPC method
+func isMethodNotFound(err error) bool {
+	rpcErr, ok := err.(rpc.Error)
+	return ok && rpcErr.ErrorCode() == methodNotFoundCode
+}
+
+/**
+Set the FeeStrategy of transactions sent afterwards by this provider, nil restores the default AutoFeeStrategy.
+*/
+func (p *provider) SetFeeStrategy(strategy FeeStrategy) {
+	p.feeLock.Lock()
+	defer p.feeLock.Unlock()
+	p.feeStrategy = strategy
+}
+
+// suggestFees returns the fees of the next transaction given by the FeeStrategy of the provider
+func (p *provider) suggestFees(ctx context.Context) (fees *Fees, err error) {
+	p.feeLock.Lock()
+	strategy := p.feeStrategy
+	p.feeLock.Unlock()
+	if strategy == nil {
+		strategy = AutoFeeStrategy
+	}
+	return strategy.Fees(ctx, p.backend)
+}
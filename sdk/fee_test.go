@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+func deployTFCWithMode(t *testing.T, mockEth *MockEthereum, legacy bool) (sdk *SDK, tfc *TFC) {
+	mockEth.Backend.SetLegacy(legacy)
+	sdk = NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err = sdk.TFC(address)
+	checkError(t, err)
+	return sdk, tfc
+}
+
+func TestFeeStrategy_Auto(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		mockEth := NewMockEthereum()
+		mockEth.Start()
+		sdk, tfc := deployTFCWithMode(t, mockEth, legacy)
+
+		pending, err := tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+		checkError(t, err)
+		_, err = pending.Wait(context.Background(), 0)
+		checkError(t, err)
+		expectedType := uint8(types.DynamicFeeTxType)
+		if legacy {
+			expectedType = types.LegacyTxType
+		}
+		if pending.Transaction().Type() != expectedType {
+			t.Fatal("expect transaction type", expectedType, "got", pending.Transaction().Type())
+		}
+		if !legacy && pending.GasFeeCap().Cmp(pending.GasTipCap()) <= 0 {
+			t.Fatal("max fee per gas should cover the base fee")
+		}
+
+		// the strategy can be forced
+		sdk.SetFeeStrategy(LegacyFeeStrategy)
+		pending, err = tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+		checkError(t, err)
+		if pending.Transaction().Type() != types.LegacyTxType {
+			t.Fatal("expect legacy transaction")
+		}
+		sdk.SetFeeStrategy(DynamicFeeStrategy)
+		_, err = tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+		if legacy && err != DynamicFeeNotSupportedErr {
+			t.Fatal("expect DynamicFeeNotSupportedErr, got", err)
+		} else if !legacy {
+			checkError(t, err)
+		}
+		mockEth.Stop()
+	}
+}
+
+func TestTFC_EstimateTFCExchangeFee(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		mockEth := NewMockEthereum()
+		mockEth.Start()
+		sdk, tfc := deployTFCWithMode(t, mockEth, legacy)
+		bridgeAccount := PredefinedAccounts[0]
+		recipient := PredefinedAccounts[1].Address()
+		amount := big.NewInt(1000)
+
+		fees, err := sdk.suggestFees(context.Background())
+		checkError(t, err)
+		quote, err := tfc.EstimateTFCExchangeFee(context.Background(), recipient, amount, bridgeAccount, 0, 0.1)
+		checkError(t, err)
+		// the fee is quoted against the worst-case price per gas
+		if quote.GasPrice.Cmp(fees.MaxGasPrice()) != 0 {
+			t.Fatal("expect gas price", fees.MaxGasPrice(), "got", quote.GasPrice)
+		}
+		if quote.RequiredTransferAmount.Cmp(new(big.Int).Mul(quote.GasPrice, big.NewInt(int64(quote.EstimatedGas)))) <= 0 {
+			t.Fatal("required transfer amount does not include the fee rate")
+		}
+
+		pending, err := tfc.SendMintTx(context.Background(), quote, "0x01", bridgeAccount, quote.RequiredTransferAmount)
+		checkError(t, err)
+		if pending.GasFeeCap().Cmp(quote.GasPrice) != 0 || pending.GasLimit() != quote.EstimatedGas {
+			t.Fatal("mint transaction does not use the estimated fee")
+		}
+		if pending.Transaction().Type() == types.LegacyTxType != legacy {
+			t.Fatal("mint transaction has wrong type")
+		}
+		_, err = pending.Wait(context.Background(), 0)
+		checkError(t, err)
+		balance, err := tfc.BalanceOf(recipient)
+		checkError(t, err)
+		if balance.Cmp(amount) != 0 {
+			t.Fatal("mint transaction is not executed")
+		}
+		mockEth.Stop()
+	}
+}
+
+func TestSDK_SpeedUp_legacy(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk, tfc := deployTFCWithMode(t, mockEth, true)
+	// the suggested gas price only covers the base fee
+	mockEth.Backend.SetMinGasPrice(big.NewInt(1))
+
+	pending, err := tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+	checkError(t, err)
+	resultCh := waitAsync(pending)
+	gasPrice := pending.GasPrice()
+	replacement, err := sdk.SpeedUp(context.Background(), pending.Hash(), PredefinedAccounts[0], 10)
+	checkError(t, err)
+	if replacement.Transaction().Type() != types.LegacyTxType {
+		t.Fatal("replacement should keep the transaction type")
+	}
+	if replacement.GasPrice().Cmp(gasPrice) <= 0 {
+		t.Fatal("replacement should have bumped gas price")
+	}
+	result := nextWaitResult(t, resultCh)
+	checkError(t, result.err)
+	if pending.Hash() != replacement.Hash() {
+		t.Fatal("waiter does not follow the mined replacement")
+	}
+}
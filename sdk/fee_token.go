@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"context"
+	"github.com/Troublor/jasmine-eth-go/token"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+)
+
+/**
+PriceOracle prices the ERC-20 tokens accepted as bridge fee payment in the fee currency (wei), see AcceptFeeToken.
+*/
+type PriceOracle interface {
+	// Price returns the price in wei of the smallest unit of the token
+	Price(ctx context.Context, token Address) (weiPerUnit *big.Rat, err error)
+}
+
+/**
+FixedPriceOracle prices the tokens at a fixed price, e.g. for stablecoins.
+*/
+type FixedPriceOracle struct {
+	weiPerUnit *big.Rat
+}
+
+func NewFixedPriceOracle(weiPerUnit *big.Rat) *FixedPriceOracle {
+	return &FixedPriceOracle{weiPerUnit: new(big.Rat).Set(weiPerUnit)}
+}
+
+func (o *FixedPriceOracle) Price(ctx context.Context, token Address) (weiPerUnit *big.Rat, err error) {
+	return new(big.Rat).Set(o.weiPerUnit), nil
+}
+
+// toWei converts the token amount to wei at the price, rounding down
+func toWei(amount *big.Int, weiPerUnit *big.Rat) *big.Int {
+	wei := new(big.Int).Mul(amount, weiPerUnit.Num())
+	return wei.Quo(wei, weiPerUnit.Denom())
+}
+
+// toTokenUnits converts the wei to the token amount at the price, rounding up
+func toTokenUnits(wei *big.Int, weiPerUnit *big.Rat) *big.Int {
+	units := new(big.Int).Mul(wei, weiPerUnit.Denom())
+	units.Add(units, weiPerUnit.Num())
+	units.Sub(units, big.NewInt(1))
+	return units.Quo(units, weiPerUnit.Num())
+}
+
+/**
+Accept the deposits of the ERC-20 token as bridge fee payment, which are converted to wei by the oracle (see CheckFeeDeposit).
+A nil oracle stops accepting the token.
+*/
+func (p *provider) AcceptFeeToken(token Address, oracle PriceOracle) error {
+	if !token.IsValid() {
+		return InvalidAddressError
+	}
+	p.feeTokenLock.Lock()
+	defer p.feeTokenLock.Unlock()
+	if oracle == nil {
+		delete(p.feeTokens, token.address())
+	} else {
+		p.feeTokens[token.address()] = oracle
+	}
+	return nil
+}
+
+// feeTokenOracle returns the PriceOracle of the accepted fee token, nil if the token is not accepted
+func (p *provider) feeTokenOracle(token common.Address) PriceOracle {
+	p.feeTokenLock.Lock()
+	defer p.feeTokenLock.Unlock()
+	return p.feeTokens[token]
+}
+
+/**
+FeeDeposit is a bridge fee deposit paid in ETH or an accepted ERC-20 token (see CheckFeeDeposit).
+*/
+type FeeDeposit struct {
+	Sender      Address
+	Token       Address  // the ERC-20 token paid, empty if paid in ETH
+	TokenAmount *big.Int // the amount of the ERC-20 token paid, nil if paid in ETH
+	Amount      *big.Int // the amount paid in wei, converted by the PriceOracle of the token if paid in ERC-20 token
+}
+
+// tokenFeeDeposit returns the deposit of accepted fee tokens to the bridge account in the receipt, nil if there is none
+func (p *provider) tokenFeeDeposit(ctx context.Context, receipt *types.Receipt, bridge common.Address) (deposit *FeeDeposit, err error) {
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, nil
+	}
+	var oracle PriceOracle
+	for _, log := range receipt.Logs {
+		tokenOracle := p.feeTokenOracle(log.Address)
+		if tokenOracle == nil {
+			continue
+		}
+		filterer, err := token.NewTFCTokenFilterer(log.Address, p.backend)
+		if err != nil {
+			return nil, err
+		}
+		transfer, err := filterer.ParseTransfer(*log)
+		if err != nil || transfer.To != bridge {
+			// not a Transfer event to the bridge account
+			continue
+		}
+		if deposit == nil {
+			oracle = tokenOracle
+			deposit = &FeeDeposit{Sender: Address(transfer.From.Hex()), Token: Address(log.Address.Hex()), TokenAmount: new(big.Int)}
+		} else if deposit.Token.address() != log.Address || deposit.Sender.address() != transfer.From {
+			// a deposit is paid by one sender in one token
+			return nil, InvalidDepositErr
+		}
+		deposit.TokenAmount.Add(deposit.TokenAmount, transfer.Value)
+	}
+	if deposit == nil {
+		return nil, nil
+	}
+	price, err := oracle.Price(ctx, deposit.Token)
+	if err != nil {
+		return nil, err
+	}
+	if price.Sign() <= 0 {
+		return nil, InvalidTokenPriceErr
+	}
+	deposit.Amount = toWei(deposit.TokenAmount, price)
+	return deposit, nil
+}
@@ -0,0 +1,99 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestTFC_CheckFeeDeposit_token(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	// another ERC-20 contract as the stablecoin
+	stableAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	stable, err := sdk.TFC(stableAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+	checkError(t, stable.MintSync(ctx, user.Address(), big.NewInt(1000000000), bridge))
+
+	transfer := func(to Address, amount *big.Int) string {
+		pending, err := stable.TransferTx(ctx, to, amount, user)
+		checkError(t, err)
+		_, err = pending.Wait(ctx, 0)
+		checkError(t, err)
+		return string(pending.Hash())
+	}
+	depositHash := transfer(bridge.Address(), big.NewInt(500000000))
+	// the token is not accepted yet
+	if _, err := tfc.CheckFeeDeposit(ctx, depositHash, bridge.Address(), 0); err != InvalidDepositErr {
+		t.Fatal("expect InvalidDepositErr, got", err)
+	}
+
+	// 1 unit of the token is worth 1000000 wei
+	checkError(t, sdk.AcceptFeeToken(stableAddress, NewFixedPriceOracle(big.NewRat(1000000, 1))))
+	deposit, err := tfc.CheckFeeDeposit(ctx, depositHash, bridge.Address(), 0)
+	checkError(t, err)
+	if deposit.Sender != user.Address() || deposit.Token != stableAddress ||
+		deposit.TokenAmount.Int64() != 500000000 || deposit.Amount.Int64() != 500000000000000 {
+		t.Fatal("token deposit is incorrect, got", deposit)
+	}
+	recipient, amount, err := tfc.CheckTransactionFeeDeposit(ctx, depositHash, bridge.Address(), 0)
+	checkError(t, err)
+	if recipient != user.Address() || amount.Cmp(deposit.Amount) != 0 {
+		t.Fatal("deposit amount should be converted to wei, got", amount)
+	}
+	if _, err := tfc.CheckFeeDeposit(ctx, depositHash, bridge.Address(), 1); err != UnconfirmedTransactionErr {
+		t.Fatal("expect UnconfirmedTransactionErr, got", err)
+	}
+	// transfers to other addresses are not deposits
+	otherHash := transfer(PredefinedAccounts[2].Address(), big.NewInt(100))
+	if _, err := tfc.CheckFeeDeposit(ctx, otherHash, bridge.Address(), 0); err != InvalidDepositErr {
+		t.Fatal("expect InvalidDepositErr, got", err)
+	}
+
+	// the token deposit is refunded in the token, net of gas priced by the oracle
+	refund, refundAmount, err := tfc.RefundDeposit(ctx, depositHash, bridge, RefundPolicy{})
+	checkError(t, err)
+	if refundAmount.Sign() <= 0 || refundAmount.Cmp(deposit.TokenAmount) >= 0 {
+		t.Fatal("refund should be net of gas, got", refundAmount)
+	}
+	_, err = refund.Wait(ctx, 0)
+	checkError(t, err)
+	balance, err := stable.BalanceOf(user.Address())
+	checkError(t, err)
+	expected := big.NewInt(1000000000 - 500000000 - 100)
+	if balance.Cmp(expected.Add(expected, refundAmount)) != 0 {
+		t.Fatal("sender should receive the refunded token, got", balance)
+	}
+	if _, _, err := tfc.RefundDeposit(ctx, depositHash, bridge, RefundPolicy{}); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+
+	// the token is no longer accepted
+	checkError(t, sdk.AcceptFeeToken(stableAddress, nil))
+	if _, err := tfc.CheckFeeDeposit(ctx, depositHash, bridge.Address(), 0); err != InvalidDepositErr {
+		t.Fatal("expect InvalidDepositErr, got", err)
+	}
+}
+
+func TestFeeTokenConversion(t *testing.T) {
+	price := big.NewRat(3, 2)
+	if toWei(big.NewInt(5), price).Int64() != 7 {
+		t.Fatal("wei should be rounded down")
+	}
+	if toTokenUnits(big.NewInt(7), price).Int64() != 5 {
+		t.Fatal("token units should be rounded up")
+	}
+	if toTokenUnits(big.NewInt(6), price).Int64() != 4 {
+		t.Fatal("token units should be exact")
+	}
+}
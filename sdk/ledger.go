@@ -0,0 +1,206 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/opt"
+	"sync"
+)
+
+/**
+DepositLedger records the deposit transactions used by bridge exchanges, so that each deposit is minted or refunded at most once.
+A deposit is claimed before its mint (or refund) transaction is signed, and the hash of the transaction is recorded before it is broadcast.
+*/
+type DepositLedger interface {
+	// Claim atomically claims the deposit transaction, DepositUsedErr is returned if it has been claimed
+	Claim(depositHash Hash) error
+	// Release releases the claim of the deposit transaction whose mint transaction is not broadcast, so that it can be claimed again
+	Release(depositHash Hash) error
+	// Record records the hash of the mint (or refund) transaction of the claimed deposit transaction
+	Record(depositHash Hash, mintHash Hash) error
+	// Lookup returns whether the deposit transaction is claimed, and the hash of its mint (or refund) transaction if recorded.
+	// A claimed deposit without mint transaction is being minted, or the process crashed before recording it.
+	// The recorded transaction may not have reached the network if sending it failed ambiguously (e.g. timed out),
+	// which should be checked (e.g. by LoadPendingTx) before the claim is released manually.
+	Lookup(depositHash Hash) (claimed bool, mintHash Hash, err error)
+}
+
+// normalizeHash returns the 0x-prefixed lower case hex of the hash, so that the same hash in different cases is the same key
+func normalizeHash(hash Hash) Hash {
+	return Hash(common.HexToHash(string(hash)).Hex())
+}
+
+/**
+MemoryDepositLedger keeps the deposits in memory, which are lost when the process exits.
+It is the default DepositLedger of the SDK.
+*/
+type MemoryDepositLedger struct {
+	lock     sync.Mutex
+	deposits map[Hash]Hash // deposit hash => mint hash
+}
+
+func NewMemoryDepositLedger() *MemoryDepositLedger {
+	return &MemoryDepositLedger{deposits: make(map[Hash]Hash)}
+}
+
+func (l *MemoryDepositLedger) Claim(depositHash Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	depositHash = normalizeHash(depositHash)
+	if _, claimed := l.deposits[depositHash]; claimed {
+		return DepositUsedErr
+	}
+	l.deposits[depositHash] = ""
+	return nil
+}
+
+func (l *MemoryDepositLedger) Release(depositHash Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	delete(l.deposits, normalizeHash(depositHash))
+	return nil
+}
+
+func (l *MemoryDepositLedger) Record(depositHash Hash, mintHash Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.deposits[normalizeHash(depositHash)] = mintHash
+	return nil
+}
+
+func (l *MemoryDepositLedger) Lookup(depositHash Hash) (claimed bool, mintHash Hash, err error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	mintHash, claimed = l.deposits[normalizeHash(depositHash)]
+	return claimed, mintHash, nil
+}
+
+/**
+LevelDBDepositLedger persists the deposits in a LevelDB database on disk, and every write is synced before it returns.
+The database can only be opened by one process at a time.
+*/
+type LevelDBDepositLedger struct {
+	lock sync.Mutex // makes claim (read then write) atomic
+	db   *leveldb.DB
+}
+
+/**
+Open (or create) the LevelDBDepositLedger in the directory.
+*/
+func OpenLevelDBDepositLedger(dir string) (ledger *LevelDBDepositLedger, err error) {
+	db, err := leveldb.OpenFile(dir, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBDepositLedger{db: db}, nil
+}
+
+func (l *LevelDBDepositLedger) Close() error {
+	return l.db.Close()
+}
+
+var syncWrite = &opt.WriteOptions{Sync: true}
+
+func (l *LevelDBDepositLedger) Claim(depositHash Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	key := []byte(normalizeHash(depositHash))
+	claimed, err := l.db.Has(key, nil)
+	if err != nil {
+		return err
+	}
+	if claimed {
+		return DepositUsedErr
+	}
+	return l.db.Put(key, nil, syncWrite)
+}
+
+func (l *LevelDBDepositLedger) Release(depositHash Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.db.Delete([]byte(normalizeHash(depositHash)), syncWrite)
+}
+
+func (l *LevelDBDepositLedger) Record(depositHash Hash, mintHash Hash) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return l.db.Put([]byte(normalizeHash(depositHash)), []byte(mintHash), syncWrite)
+}
+
+func (l *LevelDBDepositLedger) Lookup(depositHash Hash) (claimed bool, mintHash Hash, err error) {
+	value, err := l.db.Get([]byte(normalizeHash(depositHash)), nil)
+	if err == leveldb.ErrNotFound {
+		return false, "", nil
+	} else if err != nil {
+		return false, "", err
+	}
+	return true, Hash(value), nil
+}
+
+/**
+Set the DepositLedger used by the bridge exchanges (BridgeTFCExchange, SendMintTx and their variants) of this provider.
+*/
+func (p *provider) SetDepositLedger(ledger DepositLedger) {
+	p.ledgerLock.Lock()
+	defer p.ledgerLock.Unlock()
+	p.ledger = ledger
+}
+
+/**
+Returns the DepositLedger used by the bridge exchanges of this provider, a MemoryDepositLedger by default.
+*/
+func (p *provider) DepositLedger() DepositLedger {
+	p.ledgerLock.Lock()
+	defer p.ledgerLock.Unlock()
+	return p.ledger
+}
+
+/**
+sendForDeposit claims the deposit transactions (or other keys used at most once, e.g. of quotes) in the DepositLedger,
+and then sends their mint (or refund) transaction by send, which must call broadcast with each signed transaction instead of sending it.
+The hash of the transaction is recorded before it is broadcast, and the claims are released only if no transaction may have been broadcast,
+since an error of sending (e.g. a timeout) does not mean that the transaction has not reached the network.
+*/
+func (p *provider) sendForDeposit(ctx context.Context, depositHashes []Hash, send func(broadcast func(tx *types.Transaction) error) (*PendingTx, error)) (pending *PendingTx, err error) {
+	ledger := p.DepositLedger()
+	// release releases the claims of depositHashes[:n]
+	release := func(n int) error {
+		for _, depositHash := range depositHashes[:n] {
+			if err := ledger.Release(depositHash); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i, depositHash := range depositHashes {
+		if err := ledger.Claim(depositHash); err != nil {
+			if releaseErr := release(i); releaseErr != nil {
+				return nil, releaseErr
+			}
+			return nil, err
+		}
+	}
+	broadcast := false // whether a transaction may have been broadcast
+	pending, err = send(func(tx *types.Transaction) error {
+		for _, depositHash := range depositHashes {
+			if err := ledger.Record(depositHash, Hash(tx.Hash().Hex())); err != nil {
+				return err
+			}
+		}
+		if err := p.backend.SendTransaction(ctx, tx); err != nil {
+			// a nonce error rejects the transaction for sure, which is signed again with another nonce
+			broadcast = broadcast || !isNonceErr(err)
+			return err
+		}
+		broadcast = true
+		return nil
+	})
+	if err != nil && !broadcast {
+		if releaseErr := release(len(depositHashes)); releaseErr != nil {
+			return nil, releaseErr
+		}
+	}
+	return pending, err
+}
@@ -0,0 +1,207 @@
+package sdk
+
+import (
+	"context"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func testDepositLedger(t *testing.T, ledger DepositLedger) {
+	depositHash := Hash("0x37E137A35944045A02C78E994B5332CBA9ACF62595ACCD31829C1F1B08CA5BA8")
+	mintHash := Hash("0x0e87e93aa08fd149f4f66e6939543b220b2ac77697f786c0ca5e4e88022c564d")
+
+	claimed, _, err := ledger.Lookup(depositHash)
+	checkError(t, err)
+	if claimed {
+		t.Fatal("deposit should not be claimed")
+	}
+	checkError(t, ledger.Claim(depositHash))
+	// hashes are case insensitive
+	if err := ledger.Claim(Hash(strings.ToLower(string(depositHash)))); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+	checkError(t, ledger.Release(depositHash))
+	checkError(t, ledger.Claim(depositHash))
+	checkError(t, ledger.Record(depositHash, mintHash))
+	claimed, recorded, err := ledger.Lookup(depositHash)
+	checkError(t, err)
+	if !claimed || recorded != mintHash {
+		t.Fatal("mint transaction is not recorded")
+	}
+	if err := ledger.Claim(depositHash); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+}
+
+func TestMemoryDepositLedger(t *testing.T) {
+	testDepositLedger(t, NewMemoryDepositLedger())
+}
+
+func TestLevelDBDepositLedger(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ledger")
+	checkError(t, err)
+	defer os.RemoveAll(dir)
+	ledger, err := OpenLevelDBDepositLedger(dir)
+	checkError(t, err)
+	testDepositLedger(t, ledger)
+	checkError(t, ledger.Close())
+
+	// the deposits survive reopening
+	ledger, err = OpenLevelDBDepositLedger(dir)
+	checkError(t, err)
+	defer ledger.Close()
+	if err := ledger.Claim("0x37e137a35944045a02c78e994b5332cba9acf62595accd31829c1f1b08ca5ba8"); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+}
+
+func TestTFC_BridgeTFCExchange_once(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	dir, err := ioutil.TempDir("", "ledger")
+	checkError(t, err)
+	defer os.RemoveAll(dir)
+	ledger, err := OpenLevelDBDepositLedger(dir)
+	checkError(t, err)
+	defer ledger.Close()
+	sdk.SetDepositLedger(ledger)
+
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+	depositHash := depositTo(t, mockEth.Backend, user, bridge.Address(), big.NewInt(1000000000000000000))
+
+	// the claim is released if the mint transaction cannot be sent, e.g. the minter does not have the role
+	_, _, err = tfc.BridgeTFCExchangeAsync(ctx, depositHash, big.NewInt(100), PredefinedAccounts[2], 0)
+	if err == nil {
+		t.Fatal("mint without minter role should fail")
+	}
+	if claimed, _, err := ledger.Lookup(Hash(depositHash)); err != nil || claimed {
+		t.Fatal("claim should be released")
+	}
+
+	// concurrent submissions of the same deposit mint exactly once
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	mintHashes := make(chan string, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, mintHash, err := tfc.BridgeTFCExchangeAsync(ctx, depositHash, big.NewInt(100), bridge, 0)
+			results <- err
+			if err == nil {
+				mintHashes <- mintHash
+			}
+		}()
+	}
+	wg.Wait()
+	close(results)
+	close(mintHashes)
+	succeeded := 0
+	for err := range results {
+		if err == nil {
+			succeeded++
+		} else if err != DepositUsedErr {
+			t.Fatal(err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatal("expect exactly one mint, got", succeeded)
+	}
+	mintHash := <-mintHashes
+	if _, recorded, err := ledger.Lookup(Hash(depositHash)); err != nil || recorded != Hash(mintHash) {
+		t.Fatal("mint transaction is not recorded")
+	}
+	doneCh, errCh := tfc.UntilBridgeTFCExchangeComplete(ctx, mintHash, 0)
+	select {
+	case <-doneCh:
+	case err := <-errCh:
+		t.Fatal(err)
+	}
+
+	// double submission via the other variants
+	_, transactionHashErr, _, _ := tfc.BridgeTFCExchange(ctx, depositHash, big.NewInt(100), bridge, 0)
+	if transactionHashErr != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", transactionHashErr)
+	}
+	_, _, err = tfc.BridgeTFCExchangeTx(ctx, strings.ToUpper(depositHash[2:]), big.NewInt(100), bridge, 0)
+	if err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+	balance, err := tfc.BalanceOf(user.Address())
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(100)) != 0 {
+		t.Fatal("recipient should be minted once, got", balance)
+	}
+}
+
+func TestTFC_SendMintTx_once(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	crashing := &crashingBackend{MockBackend: mockEth.Backend}
+	sdk := NewSDKWithBackend(crashing)
+
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+	quote, err := tfc.EstimateTFCExchangeFee(ctx, user.Address(), big.NewInt(100), bridge, 0, 0.1)
+	checkError(t, err)
+	depositHash := depositTo(t, mockEth.Backend, user, bridge.Address(), quote.RequiredTransferAmount)
+
+	// the quoted mint path claims the deposit and the quote
+	if _, err := tfc.SendMintTx(ctx, quote, depositHash, bridge, new(big.Int).Sub(quote.RequiredTransferAmount, big.NewInt(1))); err != DepositBelowQuoteErr {
+		t.Fatal("expect DepositBelowQuoteErr, got", err)
+	}
+	pending, err := tfc.SendMintTx(ctx, quote, depositHash, bridge, quote.RequiredTransferAmount)
+	checkError(t, err)
+	if _, recorded, err := tfc.DepositLedger().Lookup(Hash(depositHash)); err != nil || recorded != pending.Hash() {
+		t.Fatal("mint transaction is not recorded")
+	}
+	otherHash := depositTo(t, mockEth.Backend, user, bridge.Address(), quote.RequiredTransferAmount)
+	if _, err := tfc.SendMintTx(ctx, quote, otherHash, bridge, quote.RequiredTransferAmount); err != QuoteUsedErr {
+		t.Fatal("expect QuoteUsedErr, got", err)
+	}
+	another, err := tfc.EstimateTFCExchangeFee(ctx, user.Address(), big.NewInt(100), bridge, 0, 0.1)
+	checkError(t, err)
+	if _, err := tfc.SendMintTx(ctx, another, depositHash, bridge, another.RequiredTransferAmount); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+	// the quote claimed along with a used deposit is released
+	_, err = tfc.SendMintTx(ctx, another, otherHash, bridge, another.RequiredTransferAmount)
+	checkError(t, err)
+	if _, _, err := tfc.BridgeTFCExchangeTx(ctx, depositHash, big.NewInt(100), bridge, 0); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+
+	// the claim is kept if sending fails after the transaction may have been broadcast
+	depositHash = depositTo(t, mockEth.Backend, user, bridge.Address(), quote.RequiredTransferAmount)
+	crashing.crashed = true
+	if _, _, err := tfc.BridgeTFCExchangeTx(ctx, depositHash, big.NewInt(100), bridge, 0); err == nil {
+		t.Fatal("mint should fail while crashed")
+	}
+	crashing.crashed = false
+	claimed, recorded, err := tfc.DepositLedger().Lookup(Hash(depositHash))
+	checkError(t, err)
+	if !claimed || recorded == "" {
+		t.Fatal("deposit should stay claimed with the mint transaction recorded")
+	}
+	if _, _, err := tfc.BridgeTFCExchangeTx(ctx, depositHash, big.NewInt(100), bridge, 0); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+}
@@ -2,13 +2,14 @@ package sdk
 
 import (
 	"context"
+	"encoding/hex"
 	"github.com/Troublor/jasmine-eth-go/token"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
 	solsha3 "github.com/offchainlabs/go-solidity-sha3"
 	"github.com/status-im/keycard-go/hexutils"
 	"math/big"
@@ -27,12 +28,16 @@ type Manager struct {
 Create a new TFC instance by providing the sdk object and the Address of TFC ERC20 contract
 */
 func NewManager(backend Backend, managerAddress Address) (manager *Manager, err error) {
+	return newManager(NewProvider(backend), managerAddress)
+}
+
+func newManager(p *provider, managerAddress Address) (manager *Manager, err error) {
 	manager = &Manager{
 		address:  managerAddress.address(),
-		backend:  backend,
-		provider: NewProvider(backend),
+		backend:  p.backend,
+		provider: p,
 	}
-	manager.contract, err = token.NewTFCManager(common.HexToAddress(string(managerAddress)), backend)
+	manager.contract, err = token.NewTFCManager(common.HexToAddress(string(managerAddress)), p.backend)
 	if err != nil {
 		return nil, err
 	}
@@ -64,20 +69,32 @@ func (manager *Manager) Signer() (signerAddress Address, err error) {
 	return Address(addr.Hex()), err
 }
 
+/**
+Reserve an unused nonce for a TFC claim with the NonceAllocator of the manager, so that each call returns a different nonce.
+The reservation is kept until the claim is redeemed, or released by the NonceAllocator if the claim is never handed out.
+*/
 func (manager *Manager) GetUnusedNonce() (nonce *big.Int, err error) {
-	for nonce = big.NewInt(0); ; nonce.Add(nonce, big.NewInt(1)) {
-		used, err := manager.IsNonceUsed(nonce)
-		if err != nil {
-			return nil, err
-		}
-		if !used {
-			return nonce, nil
-		}
-	}
+	return manager.NonceAllocator().Reserve(context.Background())
 }
 
+/**
+Sign a TFC claim with the Signer of signer, which must be the signer of TFC Manager contract (see Manager.Signer).
+The claim can be submitted by the recipient via ClaimTFC.
+*/
 func (manager *Manager) SignTFCClaim(recipient Address, amount *big.Int, nonce *big.Int, signer *Account) (signature string, err error) {
-	hash := solsha3.SoliditySHA3(
+	// signed as personal message, i.e. prefixed with "\x19Ethereum Signed Message:\n32"
+	sig, err := signer.signer.SignMessage(context.Background(), manager.claimHash(recipient, amount, nonce))
+	if err != nil {
+		return "", err
+	}
+
+	signature = "0x" + hexutils.BytesToHex(sig)
+	return signature, nil
+}
+
+// claimHash returns the hash of the TFC claim, which is signed as personal message
+func (manager *Manager) claimHash(recipient Address, amount *big.Int, nonce *big.Int) []byte {
+	return solsha3.SoliditySHA3(
 		[]string{"address", "uint256", "uint256", "address"},
 		[]interface{}{
 			recipient.address().Hex(),
@@ -86,48 +103,162 @@ func (manager *Manager) SignTFCClaim(recipient Address, amount *big.Int, nonce *
 			manager.address.Hex(),
 		},
 	)
+}
 
-	hash = solsha3.SoliditySHA3(
-		[]string{"string", "bytes32"},
-		[]interface{}{
-			"\x19Ethereum Signed Message:\n32",
-			hash,
+/**
+TFCClaimVerdict is the result of verifying a TFC claim off-chain (see VerifyTFCClaim).
+*/
+type TFCClaimVerdict struct {
+	// ValidSignature is true if the claim is signed by the signer of TFC Manager contract
+	ValidSignature bool
+	// Signer is the address recovered from the signature, empty if the signature is malformed
+	Signer Address
+	// NonceUsed is true if the nonce of the claim has been redeemed
+	NonceUsed bool
+}
+
+// Claimable returns true if the claim can be redeemed by ClaimTFC, i.e. the signature is valid and the nonce is not used
+func (verdict *TFCClaimVerdict) Claimable() bool {
+	return verdict.ValidSignature && !verdict.NonceUsed
+}
+
+/**
+Verify the TFC claim signature (see SignTFCClaim) off-chain, by recovering its signer and checking the nonce,
+so that invalid claims can be rejected without sending them to the chain.
+A malformed signature results in a verdict without signer rather than an error.
+*/
+func (manager *Manager) VerifyTFCClaim(recipient Address, amount *big.Int, nonce *big.Int, signature string) (verdict *TFCClaimVerdict, err error) {
+	if !recipient.IsValid() {
+		return nil, InvalidAddressError
+	}
+	return manager.verifyClaim(nonce, signature, func(sig []byte) (common.Address, error) {
+		return recoverPersonalSigner(manager.claimHash(recipient, amount, nonce), sig)
+	})
+}
+
+// verifyClaim checks the signer recovered from the signature by recoverSigner, and the nonce
+func (manager *Manager) verifyClaim(nonce *big.Int, signature string, recoverSigner func(sig []byte) (common.Address, error)) (verdict *TFCClaimVerdict, err error) {
+	verdict = &TFCClaimVerdict{}
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err == nil {
+		if signer, err := recoverSigner(sig); err == nil {
+			verdict.Signer = Address(signer.Hex())
+		}
+	}
+	if verdict.Signer != "" {
+		expected, err := manager.contract.Signer(nil)
+		if err != nil {
+			return nil, err
+		}
+		verdict.ValidSignature = verdict.Signer.address() == expected
+	}
+	verdict.NonceUsed, err = manager.IsNonceUsed(nonce)
+	if err != nil {
+		return nil, err
+	}
+	return verdict, nil
+}
+
+// name and version of the EIP-712 domain of TFC claims
+const (
+	TFCClaimDomainName    = "TFC Manager"
+	TFCClaimDomainVersion = "1"
+)
+
+/**
+Returns the EIP-712 typed data of the TFC claim, whose domain is bound to the chain and this Manager contract,
+so that the claim cannot be replayed on another chain or contract. Wallets can show it to the signer via eth_signTypedData_v4.
+*/
+func (manager *Manager) TFCClaimTypedData(ctx context.Context, recipient Address, amount *big.Int, nonce *big.Int) (typedData *TypedData, err error) {
+	if !recipient.IsValid() {
+		return nil, InvalidAddressError
+	}
+	if amount == nil || nonce == nil {
+		return nil, InvalidTypedDataErr
+	}
+	chainID, err := manager.provider.chainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &TypedData{
+		Types: TypedDataTypes{
+			eip712DomainType: {
+				{Name: "name", Type: "string"},
+				{Name: "version", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "verifyingContract", Type: "address"},
+			},
+			"TFCClaim": {
+				{Name: "recipient", Type: "address"},
+				{Name: "amount", Type: "uint256"},
+				{Name: "nonce", Type: "uint256"},
+			},
 		},
-	)
+		PrimaryType: "TFCClaim",
+		Domain: map[string]interface{}{
+			"name":              TFCClaimDomainName,
+			"version":           TFCClaimDomainVersion,
+			"chainId":           chainID,
+			"verifyingContract": manager.address.Hex(),
+		},
+		// uint256 as decimal strings, which survive JSON in wallets
+		Message: map[string]interface{}{
+			"recipient": recipient.address().Hex(),
+			"amount":    amount.String(),
+			"nonce":     nonce.String(),
+		},
+	}, nil
+}
 
-	sig, err := crypto.Sign(hash[:], signer.privateKey)
+/**
+Sign the TFC claim as EIP-712 typed data (see TFCClaimTypedData) with the Signer of signer, which should be the signer of TFC Manager contract.
+Note that the deployed Manager contract only accepts claims signed by SignTFCClaim,
+so typed claims are for verifiers which follow EIP-712, e.g. VerifyTFCClaimTyped.
+*/
+func (manager *Manager) SignTFCClaimTyped(ctx context.Context, recipient Address, amount *big.Int, nonce *big.Int, signer *Account) (signature string, err error) {
+	typedData, err := manager.TFCClaimTypedData(ctx, recipient, amount, nonce)
 	if err != nil {
 		return "", err
 	}
-	// weird Ethereum quirk
-	sig[64] += 27
+	sig, err := signer.signer.SignTypedData(ctx, typedData)
+	if err != nil {
+		return "", err
+	}
+	return hexutil.Encode(sig), nil
+}
 
-	signature = "0x" + hexutils.BytesToHex(sig)
-	return signature, nil
+/**
+Verify the TFC claim signature of SignTFCClaimTyped off-chain, the same as VerifyTFCClaim does for SignTFCClaim.
+*/
+func (manager *Manager) VerifyTFCClaimTyped(ctx context.Context, recipient Address, amount *big.Int, nonce *big.Int, signature string) (verdict *TFCClaimVerdict, err error) {
+	typedData, err := manager.TFCClaimTypedData(ctx, recipient, amount, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return manager.verifyClaim(nonce, signature, func(sig []byte) (common.Address, error) {
+		return recoverTypedDataSigner(typedData, sig)
+	})
 }
 
-func (manager *Manager) ClaimTFC(ctx context.Context, amount *big.Int, nonce *big.Int, signature string, claimer *Account) (doneCh chan interface{}, errCh chan error) {
-	doneCh = make(chan interface{}, 1)
-	errCh = make(chan error, 1)
-	auth := bind.NewKeyedTransactor(claimer.privateKey)
+/**
+Claim TFC using the signature signed by the signer of TFC Manager contract (see SignTFCClaim).
+The claimer must be the recipient of the signed claim.
+*/
+func (manager *Manager) ClaimTFCTx(ctx context.Context, amount *big.Int, nonce *big.Int, signature string, claimer *Account) (pending *PendingTx, err error) {
 	if strings.HasPrefix(signature, "0x") {
 		signature = signature[2:]
 	}
-	tx, err := manager.contract.ClaimTFC(auth, amount, nonce, hexutils.HexToBytes(signature))
-	if err != nil {
-		errCh <- err
-		return nil, errCh
-	}
-	receiptCh, eCh := manager.provider.AsyncTransaction(ctx, tx.Hash(), ConfirmationRequirement)
-	go func() {
-		select {
-		case <-receiptCh:
-			close(doneCh)
-		case err := <-eCh:
-			errCh <- err
-		}
-	}()
-	return doneCh, errCh
+	return manager.provider.transact(ctx, claimer, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return manager.contract.ClaimTFC(auth, amount, nonce, hexutils.HexToBytes(signature))
+	})
+}
+
+/**
+ClaimTFC is the same as ClaimTFCTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (manager *Manager) ClaimTFC(ctx context.Context, amount *big.Int, nonce *big.Int, signature string, claimer *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := manager.ClaimTFCTx(ctx, amount, nonce, signature, claimer)
+	return asyncDone(ctx, pending, err)
 }
 
 func (manager *Manager) ClaimTFCSync(ctx context.Context, amount *big.Int, nonce *big.Int, signature string, claimer *Account) (err error) {
@@ -167,7 +298,7 @@ func (manager *Manager) UntilClaimTFCComplete(ctx context.Context, recipient Add
 				Nonce     *big.Int
 				Sig       []byte
 			}{}
-			err := contractAbi.Unpack(&event, "ClaimTFC", log.Data)
+			err := contractAbi.UnpackIntoInterface(&event, "ClaimTFC", log.Data)
 			if err != nil {
 				return false
 			}
@@ -115,3 +115,124 @@ func TestManager_TFCClaim(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestManager_VerifyTFCClaim(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(context.Background(), admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+	nonce := big.NewInt(3)
+
+	sig, err := manager.SignTFCClaim(user.Address(), big.NewInt(1), nonce, admin)
+	checkError(t, err)
+	verdict, err := manager.VerifyTFCClaim(user.Address(), big.NewInt(1), nonce, sig)
+	checkError(t, err)
+	if !verdict.ValidSignature || verdict.Signer != admin.Address() || verdict.NonceUsed || !verdict.Claimable() {
+		t.Fatal("claim should be valid, got", verdict)
+	}
+	// the signature without 0x prefix is accepted as well
+	verdict, err = manager.VerifyTFCClaim(user.Address(), big.NewInt(1), nonce, sig[2:])
+	checkError(t, err)
+	if !verdict.ValidSignature {
+		t.Fatal("claim should be valid, got", verdict)
+	}
+
+	// altered claim
+	verdict, err = manager.VerifyTFCClaim(user.Address(), big.NewInt(2), nonce, sig)
+	checkError(t, err)
+	if verdict.ValidSignature || verdict.Signer == admin.Address() {
+		t.Fatal("altered claim should be invalid, got", verdict)
+	}
+	// claim signed by another account
+	other, err := manager.SignTFCClaim(user.Address(), big.NewInt(1), nonce, user)
+	checkError(t, err)
+	verdict, err = manager.VerifyTFCClaim(user.Address(), big.NewInt(1), nonce, other)
+	checkError(t, err)
+	if verdict.ValidSignature || verdict.Signer != user.Address() {
+		t.Fatal("claim signed by another account should be invalid, got", verdict)
+	}
+	// malformed signatures
+	for _, malformed := range []string{"", "0x1234", "not hex", sig[:len(sig)-2]} {
+		verdict, err = manager.VerifyTFCClaim(user.Address(), big.NewInt(1), nonce, malformed)
+		checkError(t, err)
+		if verdict.ValidSignature || verdict.Signer != "" {
+			t.Fatal("malformed signature should be invalid, got", verdict)
+		}
+	}
+
+	// redeemed claim
+	checkError(t, manager.ClaimTFCSync(context.Background(), big.NewInt(1), nonce, sig, user))
+	verdict, err = manager.VerifyTFCClaim(user.Address(), big.NewInt(1), nonce, sig)
+	checkError(t, err)
+	if !verdict.ValidSignature || !verdict.NonceUsed || verdict.Claimable() {
+		t.Fatal("nonce of redeemed claim should be used, got", verdict)
+	}
+}
+
+func TestManager_VerifyTFCClaimTyped(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+	otherAddress, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	other, err := sdk.Manager(otherAddress)
+	checkError(t, err)
+	nonce := big.NewInt(3)
+
+	// the domain is bound to the chain and the manager contract
+	typedData, err := manager.TFCClaimTypedData(ctx, user.Address(), big.NewInt(1), nonce)
+	checkError(t, err)
+	if typedData.Domain["chainId"].(*big.Int).Int64() != 1337 || typedData.Domain["verifyingContract"] != address.address().Hex() {
+		t.Fatal("domain of typed claim is incorrect", typedData.Domain)
+	}
+
+	sig, err := manager.SignTFCClaimTyped(ctx, user.Address(), big.NewInt(1), nonce, admin)
+	checkError(t, err)
+	verdict, err := manager.VerifyTFCClaimTyped(ctx, user.Address(), big.NewInt(1), nonce, sig)
+	checkError(t, err)
+	if !verdict.ValidSignature || verdict.Signer != admin.Address() || !verdict.Claimable() {
+		t.Fatal("typed claim should be valid, got", verdict)
+	}
+
+	// altered claim
+	verdict, err = manager.VerifyTFCClaimTyped(ctx, user.Address(), big.NewInt(2), nonce, sig)
+	checkError(t, err)
+	if verdict.ValidSignature {
+		t.Fatal("altered typed claim should be invalid, got", verdict)
+	}
+	// typed claim of another manager contract
+	verdict, err = other.VerifyTFCClaimTyped(ctx, user.Address(), big.NewInt(1), nonce, sig)
+	checkError(t, err)
+	if verdict.ValidSignature || verdict.Signer == admin.Address() {
+		t.Fatal("typed claim of another manager should be invalid, got", verdict)
+	}
+	// typed and personal message signatures are not interchangeable
+	personal, err := manager.SignTFCClaim(user.Address(), big.NewInt(1), nonce, admin)
+	checkError(t, err)
+	verdict, err = manager.VerifyTFCClaimTyped(ctx, user.Address(), big.NewInt(1), nonce, personal)
+	checkError(t, err)
+	if verdict.ValidSignature {
+		t.Fatal("personal message signature should be invalid as typed claim, got", verdict)
+	}
+	verdict, err = manager.VerifyTFCClaim(user.Address(), big.NewInt(1), nonce, sig)
+	checkError(t, err)
+	if verdict.ValidSignature {
+		t.Fatal("typed claim signature should be invalid as personal message, got", verdict)
+	}
+}
@@ -2,12 +2,15 @@ package sdk
 
 import (
 	"context"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/params"
 	"math/big"
+	"sync"
 )
 
 type MockEthereum struct {
@@ -22,9 +25,10 @@ func NewMockEthereum() *MockEthereum {
 
 func (eth *MockEthereum) Start() {
 	eth.ctx, eth.cancel = context.WithCancel(context.Background())
+	// subscribe before returning so that transactions sent right after Start are mined
+	txCh := make(chan *types.Transaction, 1)
+	sub := eth.Backend.SubscribeNewTransaction(txCh)
 	go func() {
-		txCh := make(chan *types.Transaction, 1)
-		sub := eth.Backend.SubscribeNewTransaction(txCh)
 		defer sub.Unsubscribe()
 		// mine block when there is transaction
 		for {
@@ -46,6 +50,14 @@ type MockBackend struct {
 	*backends.SimulatedBackend
 
 	newTxFeed event.Feed
+
+	blockGasLimit uint64
+	minGasPrice   *big.Int // transactions with lower effective priority fee are kept pending and not mined
+	legacy        bool     // whether dynamic fee transactions are not supported
+	pendingGas    uint64   // sum of gas limit of transactions in pending block
+	pool          []*types.Transaction
+	included      map[common.Hash]bool // transactions in pool which are included in pending block
+	pendingLock   sync.Mutex
 }
 
 func NewMockBackend() *MockBackend {
@@ -58,15 +70,237 @@ func NewMockBackend() *MockBackend {
 	}
 	blockGasLimit := uint64(4712388)
 	backend := backends.NewSimulatedBackend(genesisAlloc, blockGasLimit)
-	mock := &MockBackend{SimulatedBackend: backend}
+	mock := &MockBackend{
+		SimulatedBackend: backend,
+		blockGasLimit:    blockGasLimit,
+		included:         make(map[common.Hash]bool),
+	}
 	return mock
 }
 
+func mockSender(tx *types.Transaction) (common.Address, error) {
+	return types.Sender(types.LatestSignerForChainID(params.AllEthashProtocolChanges.ChainID), tx)
+}
+
+// mockMethodNotFoundError is returned by the legacy MockBackend for JSON-RPC methods introduced by EIP-1559
+type mockMethodNotFoundError struct {
+	method string
+}
+
+func (e *mockMethodNotFoundError) Error() string {
+	return "the method " + e.method + " does not exist/is not available"
+}
+
+func (e *mockMethodNotFoundError) ErrorCode() int {
+	return methodNotFoundCode
+}
+
+/**
+SendTransaction adds the transaction into the pending block like a node does:
+invalid nonces are rejected, and a pending transaction can be replaced by one with the same nonce and at least 10% higher gas price
+(or both max fee and priority fee per gas of dynamic fee transactions).
+*/
 func (b *MockBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
-	// previous transactions must have been committed
+	b.pendingLock.Lock()
+	if b.legacy && tx.Type() != types.LegacyTxType {
+		b.pendingLock.Unlock()
+		return types.ErrTxTypeNotSupported
+	}
+	if tx.Gas() > b.blockGasLimit {
+		b.pendingLock.Unlock()
+		return core.ErrGasLimit
+	}
+	from, err := mockSender(tx)
+	if err != nil {
+		b.pendingLock.Unlock()
+		return err
+	}
+	nonce, err := b.pendingNonce(ctx, from)
+	if err != nil {
+		b.pendingLock.Unlock()
+		return err
+	}
+	if tx.Nonce() < nonce {
+		// replace the pending transaction with the same nonce
+		replaced := -1
+		for i, pooled := range b.pool {
+			if pooled.Nonce() == tx.Nonce() {
+				if pooledFrom, _ := mockSender(pooled); pooledFrom == from {
+					replaced = i
+					break
+				}
+			}
+		}
+		if replaced < 0 {
+			b.pendingLock.Unlock()
+			return core.ErrNonceTooLow
+		}
+		if !bumped(tx.GasFeeCap(), b.pool[replaced].GasFeeCap()) || !bumped(tx.GasTipCap(), b.pool[replaced].GasTipCap()) {
+			b.pendingLock.Unlock()
+			return core.ErrReplaceUnderpriced
+		}
+		b.pool[replaced] = tx
+		b.rebuild()
+	} else if tx.Nonce() > nonce {
+		b.pendingLock.Unlock()
+		return core.ErrNonceTooHigh
+	} else {
+		if b.executable(tx, from) && b.pendingGas+tx.Gas() > b.blockGasLimit {
+			// the pending block is full, mine it so that the transaction goes into the next block
+			b.commit()
+		}
+		b.pool = append(b.pool, tx)
+		if b.executable(tx, from) {
+			if err := b.SimulatedBackend.SendTransaction(ctx, tx); err != nil {
+				b.pool = b.pool[:len(b.pool)-1]
+				b.pendingLock.Unlock()
+				return err
+			}
+			b.included[tx.Hash()] = true
+			b.pendingGas += tx.Gas()
+		}
+	}
+	b.pendingLock.Unlock()
+	// notify subscribers only after the transaction is in the pending block,
+	// otherwise a miner may commit before the transaction is included
 	b.newTxFeed.Send(tx)
-	err := b.SimulatedBackend.SendTransaction(ctx, tx)
-	return err
+	return nil
+}
+
+// bumped returns whether fee is at least 10% higher than the fee of the replaced transaction
+func bumped(fee *big.Int, replaced *big.Int) bool {
+	threshold := new(big.Int).Mul(replaced, big.NewInt(110))
+	return new(big.Int).Mul(fee, big.NewInt(100)).Cmp(threshold) >= 0
+}
+
+/**
+Returns whether the transaction can be included in the pending block,
+i.e. its max fee covers the base fee, its effective priority fee is not lower than the minimum gas price
+and all previous transactions of the sender are included.
+b.pendingLock must be held.
+*/
+func (b *MockBackend) executable(tx *types.Transaction, from common.Address) bool {
+	baseFee, err := b.SimulatedBackend.SuggestGasPrice(context.Background())
+	if err != nil {
+		return false
+	}
+	tip, err := tx.EffectiveGasTip(baseFee)
+	if err != nil {
+		// max fee per gas is less than the base fee
+		return false
+	}
+	if b.minGasPrice != nil && tip.Cmp(b.minGasPrice) < 0 {
+		return false
+	}
+	for _, pooled := range b.pool {
+		if pooled == tx {
+			break
+		}
+		if pooledFrom, _ := mockSender(pooled); pooledFrom == from && !b.included[pooled.Hash()] {
+			return false
+		}
+	}
+	return true
+}
+
+// rebuild the pending block with the executable transactions in pool, b.pendingLock must be held
+func (b *MockBackend) rebuild() {
+	b.SimulatedBackend.Rollback()
+	b.pendingGas = 0
+	b.included = make(map[common.Hash]bool)
+	for _, tx := range b.pool {
+		from, _ := mockSender(tx)
+		if !b.executable(tx, from) || b.pendingGas+tx.Gas() > b.blockGasLimit {
+			continue
+		}
+		if err := b.SimulatedBackend.SendTransaction(context.Background(), tx); err == nil {
+			b.included[tx.Hash()] = true
+			b.pendingGas += tx.Gas()
+		}
+	}
+}
+
+// commit the pending block, b.pendingLock must be held
+func (b *MockBackend) commit() {
+	b.SimulatedBackend.Commit()
+	var remaining []*types.Transaction
+	for _, tx := range b.pool {
+		if !b.included[tx.Hash()] {
+			remaining = append(remaining, tx)
+		}
+	}
+	b.pool = remaining
+	b.included = make(map[common.Hash]bool)
+	b.pendingGas = 0
+	if len(b.pool) > 0 {
+		b.rebuild()
+	}
+}
+
+// pendingNonce returns the nonce of the next transaction of the account, b.pendingLock must be held
+func (b *MockBackend) pendingNonce(ctx context.Context, account common.Address) (uint64, error) {
+	nonce, err := b.SimulatedBackend.NonceAt(ctx, account, nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, tx := range b.pool {
+		if from, _ := mockSender(tx); from == account {
+			nonce++
+		}
+	}
+	return nonce, nil
+}
+
+// PendingNonceAt returns the nonce of the next transaction of the account, including transactions not executable yet.
+func (b *MockBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.pendingLock.Lock()
+	defer b.pendingLock.Unlock()
+	return b.pendingNonce(ctx, account)
+}
+
+// TransactionByHash returns the transaction with the given hash, which may be pending but not executable yet.
+func (b *MockBackend) TransactionByHash(ctx context.Context, txHash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	tx, isPending, err = b.SimulatedBackend.TransactionByHash(ctx, txHash)
+	if err != ethereum.NotFound {
+		return tx, isPending, err
+	}
+	b.pendingLock.Lock()
+	defer b.pendingLock.Unlock()
+	for _, pooled := range b.pool {
+		if pooled.Hash() == txHash {
+			return pooled, true, nil
+		}
+	}
+	return nil, false, ethereum.NotFound
+}
+
+/**
+Set the minimum gas price of transactions to be mined, which is compared with the effective priority fee per gas
+(i.e. the part of the gas price above the base fee), like the miner gas price of a node.
+Transactions with lower priority fee stay pending until they are replaced, which simulates transactions stuck in the mempool.
+*/
+func (b *MockBackend) SetMinGasPrice(gasPrice *big.Int) {
+	b.pendingLock.Lock()
+	defer b.pendingLock.Unlock()
+	b.minGasPrice = gasPrice
+	b.rebuild()
+}
+
+// Commit imports all the pending transactions as a single block and starts a fresh new state.
+func (b *MockBackend) Commit() {
+	b.pendingLock.Lock()
+	defer b.pendingLock.Unlock()
+	b.commit()
+}
+
+// Rollback aborts all pending transactions, reverting to the last committed state.
+func (b *MockBackend) Rollback() {
+	b.pendingLock.Lock()
+	defer b.pendingLock.Unlock()
+	b.SimulatedBackend.Rollback()
+	b.pool = nil
+	b.included = make(map[common.Hash]bool)
+	b.pendingGas = 0
 }
 
 func (b *MockBackend) SubscribeNewTransaction(ch chan *types.Transaction) event.Subscription {
@@ -76,3 +310,29 @@ func (b *MockBackend) SubscribeNewTransaction(ch chan *types.Transaction) event.
 func (b *MockBackend) NetworkID(ctx context.Context) (*big.Int, error) {
 	return big.NewInt(2020), nil
 }
+
+func (b *MockBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.Blockchain().Config().ChainID, nil
+}
+
+/**
+Set whether the backend simulates a node without EIP-1559 support,
+which rejects dynamic fee transactions and does not suggest priority fees.
+Blocks still have base fee, which is covered by the suggested gas price.
+*/
+func (b *MockBackend) SetLegacy(legacy bool) {
+	b.pendingLock.Lock()
+	defer b.pendingLock.Unlock()
+	b.legacy = legacy
+}
+
+// SuggestGasTipCap returns the suggested priority fee per gas, which is not supported in legacy mode (see SetLegacy).
+func (b *MockBackend) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	b.pendingLock.Lock()
+	legacy := b.legacy
+	b.pendingLock.Unlock()
+	if legacy {
+		return nil, &mockMethodNotFoundError{method: "eth_maxPriorityFeePerGas"}
+	}
+	return b.SimulatedBackend.SuggestGasTipCap(ctx)
+}
@@ -0,0 +1,120 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+/**
+MockRemoteSigner is a local JSON-RPC server standing in for a remote signer (see RemoteSigner),
+which signs with the given Signers.
+*/
+type MockRemoteSigner struct {
+	server  *rpc.Server
+	signers map[common.Address]Signer
+}
+
+func NewMockRemoteSigner(signers ...Signer) *MockRemoteSigner {
+	mock := &MockRemoteSigner{
+		server:  rpc.NewServer(),
+		signers: make(map[common.Address]Signer),
+	}
+	for _, signer := range signers {
+		mock.signers[signer.Address().address()] = signer
+	}
+	if err := mock.server.RegisterName("eth", &mockSignerService{mock}); err != nil {
+		panic(err)
+	}
+	return mock
+}
+
+/**
+Returns a RemoteSigner of address connected to the mock in process.
+*/
+func (mock *MockRemoteSigner) Signer(address Address) *RemoteSigner {
+	return NewRemoteSigner(rpc.DialInProc(mock.server), address)
+}
+
+func (mock *MockRemoteSigner) Stop() {
+	mock.server.Stop()
+}
+
+type mockSignerService struct {
+	mock *MockRemoteSigner
+}
+
+func (s *mockSignerService) signer(address common.Address) (Signer, error) {
+	signer, ok := s.mock.signers[address]
+	if !ok {
+		return nil, errors.New("unknown account")
+	}
+	return signer, nil
+}
+
+// SignTransaction serves eth_signTransaction
+func (s *mockSignerService) SignTransaction(ctx context.Context, args remoteTxArgs) (*remoteSignTxResult, error) {
+	signer, err := s.signer(args.From)
+	if err != nil {
+		return nil, err
+	}
+	if args.ChainID == nil || args.Value == nil {
+		return nil, errors.New("missing chainId or value")
+	}
+	var tx *types.Transaction
+	if args.GasPrice != nil {
+		tx = types.NewTx(&types.LegacyTx{
+			Nonce:    uint64(args.Nonce),
+			GasPrice: args.GasPrice.ToInt(),
+			Gas:      uint64(args.Gas),
+			To:       args.To,
+			Value:    args.Value.ToInt(),
+			Data:     args.Data,
+		})
+	} else if args.MaxFeePerGas != nil && args.MaxPriorityFeePerGas != nil {
+		tx = types.NewTx(&types.DynamicFeeTx{
+			ChainID:    args.ChainID.ToInt(),
+			Nonce:      uint64(args.Nonce),
+			GasTipCap:  args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap:  args.MaxFeePerGas.ToInt(),
+			Gas:        uint64(args.Gas),
+			To:         args.To,
+			Value:      args.Value.ToInt(),
+			Data:       args.Data,
+			AccessList: args.AccessList,
+		})
+	} else {
+		return nil, errors.New("missing gas price")
+	}
+	signed, err := signer.SignTx(ctx, tx, (*big.Int)(args.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	raw, err := signed.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return &remoteSignTxResult{Raw: raw}, nil
+}
+
+// Sign serves eth_sign
+func (s *mockSignerService) Sign(ctx context.Context, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	signer, err := s.signer(address)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignMessage(ctx, data)
+}
+
+// SignTypedData_v4 serves eth_signTypedData_v4
+func (s *mockSignerService) SignTypedData_v4(ctx context.Context, address common.Address, typedData TypedData) (hexutil.Bytes, error) {
+	signer, err := s.signer(address)
+	if err != nil {
+		return nil, err
+	}
+	return signer.SignTypedData(ctx, &typedData)
+}
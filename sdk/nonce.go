@@ -0,0 +1,196 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NonceRetries is the number of times a transaction is re-sent with a resynced nonce after a nonce error
+var NonceRetries = 3
+
+// error messages returned by nodes when the nonce of a transaction is already used
+var nonceErrorMessages = []string{
+	"nonce too low",
+	"replacement transaction underpriced",
+	"already known",
+	"known transaction",
+}
+
+func isNonceErr(err error) bool {
+	for _, message := range nonceErrorMessages {
+		if strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+NonceManager hands out the nonces of accounts locally,
+so that transactions can be sent concurrently from one account without fetching the pending nonce each time.
+
+The nonce of an account is fetched from the chain when it is first used, or after Resync.
+Nonces of dropped transactions (see Dropped) are handed out again before new nonces, filling the gaps.
+Sends of the same account are serialized between Acquire and Release, so that transactions reach the node in nonce order.
+*/
+type NonceManager struct {
+	backend Backend
+
+	lock     sync.Mutex
+	accounts map[common.Address]*accountNonce
+}
+
+type accountNonce struct {
+	sem chan struct{} // held between Acquire and Release
+
+	lock   sync.Mutex
+	synced bool
+	next   uint64
+	gaps   []uint64 // nonces of dropped transactions which are less than next, in ascending order
+}
+
+func NewNonceManager(backend Backend) *NonceManager {
+	return &NonceManager{
+		backend:  backend,
+		accounts: make(map[common.Address]*accountNonce),
+	}
+}
+
+func (m *NonceManager) account(address common.Address) *accountNonce {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	a, ok := m.accounts[address]
+	if !ok {
+		a = &accountNonce{sem: make(chan struct{}, 1)}
+		m.accounts[address] = a
+	}
+	return a
+}
+
+/**
+Acquire the nonce for the next transaction of the account.
+It blocks until the previous acquired nonce of the account is released, or ctx is done.
+Release must be called after the transaction is sent (or failed to be sent).
+*/
+func (m *NonceManager) Acquire(ctx context.Context, address Address) (nonce uint64, err error) {
+	a := m.account(address.address())
+	select {
+	case a.sem <- struct{}{}:
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	}
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if !a.synced {
+		next, err := m.backend.PendingNonceAt(ctx, address.address())
+		if err != nil {
+			<-a.sem
+			return 0, err
+		}
+		a.next = next
+		a.gaps = nil
+		a.synced = true
+	}
+	if len(a.gaps) > 0 {
+		return a.gaps[0], nil
+	}
+	return a.next, nil
+}
+
+/**
+Release the nonce acquired by Acquire.
+sent indicates whether a transaction with the nonce has been accepted by the node.
+If not, the nonce will be handed out again.
+*/
+func (m *NonceManager) Release(address Address, nonce uint64, sent bool) {
+	a := m.account(address.address())
+	a.lock.Lock()
+	if sent && a.synced {
+		if len(a.gaps) > 0 && a.gaps[0] == nonce {
+			a.gaps = a.gaps[1:]
+		} else if nonce == a.next {
+			a.next++
+		}
+	}
+	a.lock.Unlock()
+	<-a.sem
+}
+
+/**
+Resync the nonce of the account with the chain the next time a nonce is acquired.
+This should be called when the node rejects a transaction due to its nonce, e.g. the account is used elsewhere.
+*/
+func (m *NonceManager) Resync(address Address) {
+	a := m.account(address.address())
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.synced = false
+}
+
+/**
+Dropped notifies that the transaction of the account with nonce is dropped by the node,
+so that the nonce will be handed out again to fill the gap.
+*/
+func (m *NonceManager) Dropped(address Address, nonce uint64) {
+	a := m.account(address.address())
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	if !a.synced || nonce >= a.next {
+		return
+	}
+	i := sort.Search(len(a.gaps), func(i int) bool { return a.gaps[i] >= nonce })
+	if i < len(a.gaps) && a.gaps[i] == nonce {
+		return
+	}
+	a.gaps = append(a.gaps, 0)
+	copy(a.gaps[i+1:], a.gaps[i:])
+	a.gaps[i] = nonce
+}
+
+/**
+Sends a transaction of sender with the nonce handed out by the NonceManager of the provider,
+and the fees given by the FeeStrategy of the provider.
+send is called with the transactor of sender (signing with the Signer of sender), and is called again with a resynced nonce if the node rejects the nonce.
+*/
+func (p *provider) transact(ctx context.Context, sender *Account, send func(auth *bind.TransactOpts) (*types.Transaction, error)) (pending *PendingTx, err error) {
+	fees, err := p.suggestFees(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return p.transactWithFees(ctx, sender, fees, send)
+}
+
+/**
+transactWithFees is the same as transact, except that the transaction is sent with the given fees.
+*/
+func (p *provider) transactWithFees(ctx context.Context, sender *Account, fees *Fees, send func(auth *bind.TransactOpts) (*types.Transaction, error)) (pending *PendingTx, err error) {
+	chainID, err := p.chainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for attempt := 0; ; attempt++ {
+		nonce, err := p.nonces.Acquire(ctx, sender.Address())
+		if err != nil {
+			return nil, err
+		}
+		auth := sender.transactor(ctx, chainID)
+		auth.Nonce = new(big.Int).SetUint64(nonce)
+		fees.apply(auth)
+		tx, err := send(auth)
+		if err == nil {
+			p.nonces.Release(sender.Address(), nonce, true)
+			return p.newPendingTx(tx, sender), nil
+		}
+		p.nonces.Release(sender.Address(), nonce, false)
+		if !isNonceErr(err) || attempt >= NonceRetries {
+			return nil, err
+		}
+		p.nonces.Resync(sender.Address())
+	}
+}
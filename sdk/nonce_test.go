@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+func TestTFC_ConcurrentMints(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	// the account is used outside of the nonce manager, which requires a resync
+	signedTx := prepareEthTransferTransaction(mockEth.Backend, PredefinedAccounts[0], PredefinedAccounts[1], big.NewInt(1))
+	checkError(t, mockEth.Backend.SendTransaction(context.Background(), signedTx))
+
+	const mints = 30
+	var wg sync.WaitGroup
+	errs := make(chan error, mints)
+	nonces := make(chan uint64, mints)
+	for i := 0; i < mints; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			recipient := PredefinedAccounts[1+i%(len(PredefinedAccounts)-1)].Address()
+			pending, err := tfc.MintTx(context.Background(), recipient, big.NewInt(10), PredefinedAccounts[0])
+			if err != nil {
+				errs <- err
+				return
+			}
+			nonces <- pending.Nonce()
+			_, err = pending.Wait(context.Background(), 0)
+			if err == nil {
+				err = pending.RevertError(context.Background())
+			}
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	close(nonces)
+	for err := range errs {
+		checkError(t, err)
+	}
+	seen := make(map[uint64]bool)
+	for nonce := range nonces {
+		if seen[nonce] {
+			t.Fatal("nonce is used twice:", nonce)
+		}
+		seen[nonce] = true
+	}
+
+	totalSupply, err := tfc.TotalSupply()
+	checkError(t, err)
+	if totalSupply.Cmp(big.NewInt(10*mints)) != 0 {
+		t.Fatal("expect total supply", 10*mints, "got", totalSupply)
+	}
+}
+
+func TestNonceManager_Dropped(t *testing.T) {
+	backend := NewMockBackend()
+	nonces := NewNonceManager(backend)
+	account := PredefinedAccounts[0].Address()
+
+	for i := uint64(0); i < 3; i++ {
+		nonce, err := nonces.Acquire(context.Background(), account)
+		checkError(t, err)
+		if nonce != i {
+			t.Fatal("expect nonce", i, "got", nonce)
+		}
+		nonces.Release(account, nonce, true)
+	}
+
+	// a nonce which is not sent is handed out again
+	nonce, err := nonces.Acquire(context.Background(), account)
+	checkError(t, err)
+	nonces.Release(account, nonce, false)
+	if nonce != 3 {
+		t.Fatal("expect nonce 3, got", nonce)
+	}
+
+	// the gaps of dropped transactions are filled first
+	nonces.Dropped(account, 1)
+	nonces.Dropped(account, 0)
+	for _, expected := range []uint64{0, 1, 3} {
+		nonce, err := nonces.Acquire(context.Background(), account)
+		checkError(t, err)
+		nonces.Release(account, nonce, true)
+		if nonce != expected {
+			t.Fatal("expect nonce", expected, "got", nonce)
+		}
+	}
+
+	// resync fetches the pending nonce from chain
+	nonces.Resync(account)
+	nonce, err = nonces.Acquire(context.Background(), account)
+	checkError(t, err)
+	nonces.Release(account, nonce, false)
+	if nonce != 0 {
+		t.Fatal("expect nonce 0 after resync, got", nonce)
+	}
+}
@@ -0,0 +1,340 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sync"
+)
+
+/**
+PendingTx is a handle of a sent transaction.
+
+It carries the signed transaction, and the receipt once the transaction is mined.
+Wait() can be used to wait for confirmations, and the handle can be recovered from the transaction hash
+(see LoadPendingTx) to resume waiting, e.g. after a restart.
+If the transaction is replaced (see SDK.SpeedUp), the handle follows the replacement which is mined.
+*/
+type PendingTx struct {
+	provider *provider
+
+	from    Address
+	account *Account // nil if the handle is loaded by hash, in which case the transaction cannot be bumped
+	cancel  bool     // whether the transaction is a cancellation of another one
+
+	lock       sync.Mutex
+	tx         *types.Transaction
+	status     ConfirmationStatus
+	receipt    *types.Receipt
+	bumpPolicy *BumpPolicy
+}
+
+func (p *provider) newPendingTx(tx *types.Transaction, sender *Account) *PendingTx {
+	return &PendingTx{
+		provider:   p,
+		tx:         tx,
+		from:       sender.Address(),
+		account:    sender,
+		status:     TxPending,
+		bumpPolicy: p.defaultBumpPolicy(),
+	}
+}
+
+/**
+Recovers the PendingTx handle of a sent transaction by its hash.
+The transaction must be known by the node, i.e. either pending or mined.
+*/
+func (p *provider) LoadPendingTx(ctx context.Context, txHash Hash) (pending *PendingTx, err error) {
+	tx, isPending, err := p.backend.TransactionByHash(ctx, common.HexToHash(string(txHash)))
+	if err == ethereum.NotFound {
+		return nil, UnknownTransactionHashErr
+	} else if err != nil {
+		return nil, err
+	}
+	signer, err := p.signer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	from, err := types.Sender(signer, tx)
+	if err != nil {
+		return nil, err
+	}
+	pending = &PendingTx{
+		provider: p,
+		tx:       tx,
+		from:     Address(from.Hex()),
+		cancel:   p.replacements.isCancel(tx.Hash()),
+		status:   TxPending,
+	}
+	if !isPending {
+		receipt, err := p.backend.TransactionReceipt(ctx, tx.Hash())
+		if err != nil && err != ethereum.NotFound {
+			return nil, err
+		}
+		if receipt != nil {
+			pending.status = TxMined
+			pending.receipt = receipt
+		}
+	}
+	return pending, nil
+}
+
+// Hash returns the hash of the transaction, or the hash of the replacement if it is mined instead
+func (pending *PendingTx) Hash() Hash {
+	return Hash(pending.Transaction().Hash().Hex())
+}
+
+// From returns the sender of the transaction
+func (pending *PendingTx) From() Address {
+	return pending.from
+}
+
+// Nonce returns the nonce of the transaction
+func (pending *PendingTx) Nonce() uint64 {
+	return pending.Transaction().Nonce()
+}
+
+// GasLimit returns the gas limit of the transaction
+func (pending *PendingTx) GasLimit() uint64 {
+	return pending.Transaction().Gas()
+}
+
+// GasPrice returns the gas price of the transaction, which is the max fee per gas if it is a dynamic fee transaction
+func (pending *PendingTx) GasPrice() *big.Int {
+	return new(big.Int).Set(pending.Transaction().GasPrice())
+}
+
+// GasTipCap returns the max priority fee per gas of the transaction, which is the gas price if it is a legacy transaction
+func (pending *PendingTx) GasTipCap() *big.Int {
+	return new(big.Int).Set(pending.Transaction().GasTipCap())
+}
+
+// GasFeeCap returns the max fee per gas of the transaction, which is the gas price if it is a legacy transaction
+func (pending *PendingTx) GasFeeCap() *big.Int {
+	return new(big.Int).Set(pending.Transaction().GasFeeCap())
+}
+
+// Transaction returns the signed transaction, which can be re-broadcast
+func (pending *PendingTx) Transaction() *types.Transaction {
+	pending.lock.Lock()
+	defer pending.lock.Unlock()
+	return pending.tx
+}
+
+/**
+Set the BumpPolicy used by Wait to replace the transaction if it is stuck, nil disables automatic bumping.
+The default policy is the one of the SDK (see SetBumpPolicy) when the transaction is sent.
+Transactions recovered by LoadPendingTx cannot be bumped automatically since the private key is unknown.
+*/
+func (pending *PendingTx) SetBumpPolicy(policy *BumpPolicy) {
+	pending.lock.Lock()
+	defer pending.lock.Unlock()
+	pending.bumpPolicy = policy
+}
+
+// Status returns the status of the transaction observed so far (by Wait or LoadPendingTx)
+func (pending *PendingTx) Status() ConfirmationStatus {
+	pending.lock.Lock()
+	defer pending.lock.Unlock()
+	return pending.status
+}
+
+// Receipt returns the receipt of the transaction, nil if the transaction is not known to be mined
+func (pending *PendingTx) Receipt() *types.Receipt {
+	pending.lock.Lock()
+	defer pending.lock.Unlock()
+	return pending.receipt
+}
+
+// Reverted returns true if the transaction is mined but its execution failed
+func (pending *PendingTx) Reverted() bool {
+	receipt := pending.Receipt()
+	return receipt != nil && receipt.Status == types.ReceiptStatusFailed
+}
+
+/**
+Returns a *RevertedError with the decoded revert reason if the transaction is mined but reverted, otherwise nil.
+*/
+func (pending *PendingTx) RevertError(ctx context.Context) error {
+	receipt := pending.Receipt()
+	if receipt == nil || receipt.Status != types.ReceiptStatusFailed {
+		return nil
+	}
+	return &RevertedError{
+		TxHash: pending.Hash(),
+		Reason: pending.provider.revertReason(ctx, pending.Transaction(), pending.from.address(), receipt.BlockNumber),
+	}
+}
+
+/**
+Wait until the transaction has the number of confirmations, and returns its receipt.
+The receipt is also returned if the transaction is reverted, which can be checked by Reverted() or RevertError().
+
+Replacements of the transaction sent by SDK.SpeedUp or SDK.Cancel (or by the BumpPolicy) are waited as well,
+and the handle switches to whichever of them is mined.
+If a cancellation is mined instead of the transaction, its receipt is returned together with TransactionCancelledErr.
+If the transaction is dropped, TransactionDroppedErr is returned and its nonce will be reused by the next transaction of the sender.
+If the nonce is used by a transaction unknown to this SDK, TransactionReplacedErr is returned.
+*/
+func (pending *PendingTx) Wait(ctx context.Context, confirmations int) (receipt *types.Receipt, err error) {
+	if confirmations < 0 {
+		return nil, errors.New("confirmation number must be non-negative")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	key := nonceKey{pending.from.address(), pending.Nonce()}
+
+	// watch the transaction and its replacements
+	events := make(chan *ConfirmationEvent)
+	errs := make(chan error, 1)
+	watched := make(map[common.Hash]*types.Transaction)
+	alive := 0
+	watch := func(tx *types.Transaction) {
+		if _, ok := watched[tx.Hash()]; ok {
+			return
+		}
+		watched[tx.Hash()] = tx
+		alive++
+		eventCh, errCh := pending.provider.tracker.Watch(ctx, tx.Hash(), confirmations)
+		go func() {
+			for {
+				select {
+				case event := <-eventCh:
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+					if event.Status == TxConfirmed || event.Status == TxDropped {
+						return
+					}
+				case err := <-errCh:
+					select {
+					case errs <- err:
+					default:
+					}
+					return
+				}
+			}
+		}()
+	}
+	watch(pending.Transaction())
+
+	// new heads are only needed to bump the transaction
+	pending.lock.Lock()
+	policy := pending.bumpPolicy
+	pending.lock.Unlock()
+	var heads chan *types.Header
+	var headErr <-chan error
+	if policy != nil && pending.account != nil && !pending.cancel {
+		heads = make(chan *types.Header, 16)
+		sub, err := pending.provider.backend.SubscribeNewHead(ctx, heads)
+		if err != nil {
+			return nil, err
+		}
+		defer sub.Unsubscribe()
+		headErr = sub.Err()
+	}
+	stuckBlocks := 0
+
+	for {
+		txs, changed := pending.provider.replacements.get(key)
+		for _, tx := range txs {
+			watch(tx)
+		}
+		select {
+		case <-changed:
+		case err := <-errs:
+			return nil, err
+		case err := <-headErr:
+			if err == nil {
+				err = SubscriptionClosedErr
+			}
+			return nil, err
+		case <-heads:
+			if pending.Status() == TxMined {
+				stuckBlocks = 0
+				continue
+			}
+			stuckBlocks++
+			if stuckBlocks < policy.Blocks {
+				continue
+			}
+			stuckBlocks = 0
+			_, err := pending.provider.replaceTx(ctx, pending.Transaction(), pending.account, policy.Percent, policy.MaxGasPrice, false)
+			if err != nil && err != TransactionNotPendingErr && err != GasPriceLimitErr && !isNonceErr(err) {
+				return nil, err
+			}
+		case event := <-events:
+			tx := watched[common.HexToHash(string(event.TransactionHash))]
+			if event.Status == TxDropped {
+				alive--
+				if alive > 0 {
+					// other replacements are still alive
+					continue
+				}
+				pending.lock.Lock()
+				pending.status = TxDropped
+				pending.receipt = nil
+				pending.lock.Unlock()
+				pending.provider.replacements.remove(key)
+				nonce, err := pending.provider.backend.NonceAt(ctx, key.from, nil)
+				if err != nil {
+					return nil, err
+				}
+				if nonce > key.nonce {
+					return nil, TransactionReplacedErr
+				}
+				pending.provider.nonces.Dropped(pending.from, key.nonce)
+				return nil, TransactionDroppedErr
+			}
+			pending.lock.Lock()
+			pending.status = event.Status
+			switch event.Status {
+			case TxReorged:
+				pending.receipt = nil
+			case TxMined, TxConfirmed:
+				pending.receipt = event.Receipt
+				pending.tx = tx
+			}
+			pending.lock.Unlock()
+			if event.Status == TxConfirmed {
+				cancelled := !pending.cancel && pending.provider.replacements.isCancel(tx.Hash())
+				pending.provider.replacements.remove(key)
+				if cancelled {
+					return event.Receipt, TransactionCancelledErr
+				}
+				return event.Receipt, nil
+			}
+		}
+	}
+}
+
+/**
+Converts the result of sending a transaction into the channel style:
+doneCh is closed when the transaction is confirmed (see ConfirmationRequirement), otherwise the error is fed to errCh.
+A reverted transaction results in a *RevertedError.
+*/
+func asyncDone(ctx context.Context, pending *PendingTx, err error) (doneCh chan interface{}, errCh chan error) {
+	doneCh = make(chan interface{}, 1)
+	errCh = make(chan error, 1)
+	if err != nil {
+		errCh <- err
+		return doneCh, errCh
+	}
+	go func() {
+		_, err := pending.Wait(ctx, ConfirmationRequirement)
+		if err == nil {
+			err = pending.RevertError(ctx)
+		}
+		if err != nil {
+			errCh <- err
+			return
+		}
+		close(doneCh)
+	}()
+	return doneCh, errCh
+}
@@ -0,0 +1,113 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPendingTx_Wait(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	pending, err := tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(1000), PredefinedAccounts[0])
+	checkError(t, err)
+	if pending.From() != PredefinedAccounts[0].Address() {
+		t.Fatal("sender of pending transaction is incorrect")
+	}
+	if pending.Hash() == "" || pending.GasLimit() == 0 || pending.GasPrice() == nil {
+		t.Fatal("pending transaction is incomplete")
+	}
+	if pending.Status() != TxPending {
+		t.Fatal("expect pending status before waiting, got", pending.Status())
+	}
+
+	receipt, err := pending.Wait(context.Background(), 0)
+	checkError(t, err)
+	if receipt == nil || receipt.TxHash.Hex() != string(pending.Hash()) {
+		t.Fatal("receipt of pending transaction is incorrect")
+	}
+	if pending.Status() != TxConfirmed || pending.Receipt() == nil || pending.Reverted() {
+		t.Fatal("pending transaction is not confirmed")
+	}
+
+	// the handle can be recovered from the hash
+	loaded, err := sdk.LoadPendingTx(context.Background(), pending.Hash())
+	checkError(t, err)
+	if loaded.Hash() != pending.Hash() || loaded.From() != pending.From() || loaded.Nonce() != pending.Nonce() {
+		t.Fatal("loaded pending transaction is incorrect")
+	}
+	if loaded.Status() != TxMined || loaded.Receipt() == nil {
+		t.Fatal("loaded pending transaction should be mined")
+	}
+
+	_, err = sdk.LoadPendingTx(context.Background(), Hash("0x0000000000000000000000000000000000000000000000000000000000000001"))
+	if err != UnknownTransactionHashErr {
+		t.Fatal("expect UnknownTransactionHashErr, got", err)
+	}
+}
+
+func TestPendingTx_Reverted(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	// mint by an account without MINTER_ROLE, the gas limit is given so that the transaction is sent anyway
+	chainID, err := mockEth.Backend.ChainID(context.Background())
+	checkError(t, err)
+	auth := PredefinedAccounts[1].transactor(context.Background(), chainID)
+	auth.GasLimit = 200000
+	tx, err := tfc.contract.Mint(auth, PredefinedAccounts[1].address, big.NewInt(1000))
+	checkError(t, err)
+	pending := tfc.newPendingTx(tx, PredefinedAccounts[1])
+
+	doneCh, errCh := asyncDone(context.Background(), pending, nil)
+	select {
+	case <-doneCh:
+		t.Fatal("reverted transaction should not be done")
+	case err := <-errCh:
+		revertedErr, ok := err.(*RevertedError)
+		if !ok {
+			t.Fatal("expect RevertedError, got", err)
+		}
+		if revertedErr.TxHash != pending.Hash() {
+			t.Fatal("hash of reverted transaction is incorrect")
+		}
+		if !strings.Contains(revertedErr.Reason, "minter role") {
+			t.Fatal("revert reason is not decoded:", revertedErr.Reason)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("reverted transaction is not detected")
+	}
+	if !pending.Reverted() {
+		t.Fatal("pending transaction should be reverted")
+	}
+
+	// AsyncTransaction also reports the revert
+	receiptCh, errCh := sdk.AsyncTransaction(context.Background(), tx.Hash(), 0)
+	select {
+	case <-receiptCh:
+		t.Fatal("reverted transaction should not be successful")
+	case err := <-errCh:
+		if _, ok := err.(*RevertedError); !ok {
+			t.Fatal("expect RevertedError, got", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("reverted transaction is not detected")
+	}
+}
@@ -4,18 +4,108 @@ import (
 	"context"
 	"errors"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"math/big"
+	"strings"
+	"sync"
 )
 
 type provider struct {
-	backend Backend
+	backend      Backend
+	tracker      *ConfirmationTracker
+	nonces       *NonceManager
+	replacements *replacementRegistry
+
+	bumpLock   sync.Mutex
+	bumpPolicy *BumpPolicy
+
+	feeLock     sync.Mutex
+	feeStrategy FeeStrategy
+
+	chainIDLock sync.Mutex
+	chainIDVal  *big.Int // cached chain ID of the backend
+
+	ledgerLock sync.Mutex
+	ledger     DepositLedger
+
+	feeTokenLock sync.Mutex
+	feeTokens    map[common.Address]PriceOracle // accepted ERC-20 tokens of bridge fee deposits
+
+	claimNonceLock sync.Mutex
+	claimNonces    map[common.Address]*ClaimNonceAllocator // nonce allocators of Manager contracts
 }
 
 func NewProvider(backend Backend) *provider {
-	return &provider{backend: backend}
+	return &provider{
+		backend:      backend,
+		tracker:      NewConfirmationTracker(backend),
+		nonces:       NewNonceManager(backend),
+		replacements: newReplacementRegistry(),
+		ledger:       NewMemoryDepositLedger(),
+		feeTokens:    make(map[common.Address]PriceOracle),
+		claimNonces:  make(map[common.Address]*ClaimNonceAllocator),
+	}
+}
+
+/**
+Returns the ConfirmationTracker shared by all transactions waited by this provider.
+*/
+func (p *provider) Tracker() *ConfirmationTracker {
+	return p.tracker
+}
+
+/**
+Returns the NonceManager which hands out the nonces of transactions sent by this provider.
+*/
+func (p *provider) Nonces() *NonceManager {
+	return p.nonces
+}
+
+/**
+Set the BumpPolicy of transactions sent afterwards by this provider, nil disables automatic bumping.
+See also PendingTx.SetBumpPolicy.
+*/
+func (p *provider) SetBumpPolicy(policy *BumpPolicy) {
+	p.bumpLock.Lock()
+	defer p.bumpLock.Unlock()
+	p.bumpPolicy = policy
+}
+
+func (p *provider) defaultBumpPolicy() *BumpPolicy {
+	p.bumpLock.Lock()
+	defer p.bumpLock.Unlock()
+	return p.bumpPolicy
+}
+
+/**
+Returns the chain ID of the backend, which is fetched once and cached.
+*/
+func (p *provider) chainID(ctx context.Context) (chainID *big.Int, err error) {
+	p.chainIDLock.Lock()
+	defer p.chainIDLock.Unlock()
+	if p.chainIDVal == nil {
+		p.chainIDVal, err = p.backend.ChainID(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.chainIDVal, nil
+}
+
+/**
+Returns the signer which recovers the sender of all types of transactions on the chain of the backend.
+*/
+func (p *provider) signer(ctx context.Context) (signer types.Signer, err error) {
+	chainID, err := p.chainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return types.LatestSignerForChainID(chainID), nil
 }
 
 func (p *provider) getConfirmationCount(ctx context.Context, blockNumber *big.Int, blockHash common.Hash) (count int, err error) {
@@ -42,64 +132,38 @@ func (p *provider) getConfirmationCount(ctx context.Context, blockNumber *big.In
 	}
 }
 
+/**
+Wait for the transaction to have confirmationNumber confirmations.
+The transaction is watched by the shared ConfirmationTracker of the provider.
+
+The receipt is fed to receiptCh once the confirmation requirement is achieved.
+If the transaction is reverted, a *RevertedError is fed to errCh instead.
+If ctx is done, the transaction is dropped or there is any other error, the error is fed to errCh.
+*/
 func (p *provider) AsyncTransaction(ctx context.Context, txHash common.Hash, confirmationNumber int) (receiptCh chan *types.Receipt, errCh chan error) {
 	if confirmationNumber < 0 {
 		panic(errors.New("confirmation number must be non-negative"))
 	}
 	receiptCh = make(chan *types.Receipt, 1)
 	errCh = make(chan error, 1)
+	eventCh, eCh := p.tracker.Watch(ctx, txHash, confirmationNumber)
 	go func() {
-		// listen to new headers
-		headerCh := make(chan *types.Header, confirmationNumber)
-		headerSub, err := p.backend.SubscribeNewHead(ctx, headerCh)
-		if err != nil {
-			// there is some error when try to subscribe new head
-			errCh <- err
-			return
-		}
-		defer headerSub.Unsubscribe()
-
-		var receipt *types.Receipt
-
-		checkTransaction := func() (waitForMoreBlocks bool) {
-			// try to fetch receipt
-			receipt, err = p.backend.TransactionReceipt(ctx, txHash)
-			if err == ethereum.NotFound || receipt == nil {
-				// transaction is still pending
-				return true
-			}
-			if err != nil {
-				errCh <- err
-				return false
-			}
-			// the transaction has already been mined
-			// check confirmation count
-			confirmationCount, err := p.getConfirmationCount(ctx, receipt.BlockNumber, receipt.BlockHash)
-			if err != nil {
-				// there is some error when try to get confirmation count
-				errCh <- err
-				return false
-			}
-			if confirmationCount >= confirmationNumber {
-				// confirmation requirement achieved
-				receiptCh <- receipt
-				return false
-			}
-			return true
-		}
-
-		// check if transaction is already confirmed
-		if waitForMoreBlocks := checkTransaction(); !waitForMoreBlocks {
-			return
-		}
 		for {
 			select {
-			case <-ctx.Done():
-				errCh <- ctx.Err()
+			case err := <-eCh:
+				errCh <- err
 				return
-			case <-headerCh:
-				waitForMoreBlocks := checkTransaction()
-				if !waitForMoreBlocks {
+			case event := <-eventCh:
+				switch event.Status {
+				case TxConfirmed:
+					if err := p.checkReceipt(ctx, event.Receipt); err != nil {
+						errCh <- err
+						return
+					}
+					receiptCh <- event.Receipt
+					return
+				case TxDropped:
+					errCh <- TransactionDroppedErr
 					return
 				}
 			}
@@ -107,3 +171,61 @@ func (p *provider) AsyncTransaction(ctx context.Context, txHash common.Hash, con
 	}()
 	return receiptCh, errCh
 }
+
+/**
+Returns a *RevertedError if the execution of the transaction of receipt is reverted, otherwise nil.
+*/
+func (p *provider) checkReceipt(ctx context.Context, receipt *types.Receipt) error {
+	if receipt.Status != types.ReceiptStatusFailed {
+		return nil
+	}
+	reason := ""
+	tx, _, err := p.backend.TransactionByHash(ctx, receipt.TxHash)
+	if err == nil {
+		signer, err := p.signer(ctx)
+		if err == nil {
+			from, err := types.Sender(signer, tx)
+			if err == nil {
+				reason = p.revertReason(ctx, tx, from, receipt.BlockNumber)
+			}
+		}
+	}
+	return &RevertedError{
+		TxHash: Hash(receipt.TxHash.Hex()),
+		Reason: reason,
+	}
+}
+
+/**
+Replays the transaction at the block it is mined in, and decodes the revert reason from the result.
+Empty string is returned if the reason is not available.
+*/
+func (p *provider) revertReason(ctx context.Context, tx *types.Transaction, from common.Address, blockNumber *big.Int) string {
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	feesOf(tx).applyCall(&msg)
+	_, err := p.backend.CallContract(ctx, msg, blockNumber)
+	if err == nil {
+		return ""
+	}
+	if dataErr, ok := err.(rpc.DataError); ok {
+		if data, ok := dataErr.ErrorData().(string); ok {
+			if raw, err := hexutil.Decode(data); err == nil {
+				if reason, err := abi.UnpackRevert(raw); err == nil {
+					return reason
+				}
+			}
+		}
+	}
+	// some nodes only include the reason in the error message
+	const prefix = "execution reverted: "
+	if strings.HasPrefix(err.Error(), prefix) {
+		return strings.TrimPrefix(err.Error(), prefix)
+	}
+	return ""
+}
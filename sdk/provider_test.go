@@ -15,7 +15,7 @@ func prepareEthTransferTransaction(backend *MockBackend, from *Account, to *Acco
 	gasPrice, _ := backend.SuggestGasPrice(context.Background())
 	tx := types.NewTransaction(nonce, to.address, amount, gasLimit, gasPrice, nil)
 	chainId := backend.Blockchain().Config().ChainID
-	signedTx, _ = types.SignTx(tx, types.NewEIP155Signer(chainId), from.privateKey)
+	signedTx, _ = from.signer.SignTx(context.Background(), tx, chainId)
 	return signedTx
 }
 
@@ -0,0 +1,276 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"strings"
+)
+
+// QueryWindow is the max number of blocks requested in one eth_getLogs call when querying historical events.
+// The window is shrunk automatically if the node rejects the request due to too many results,
+// and grown back (up to QueryWindow) after each successful request.
+var QueryWindow uint64 = 5000
+
+// error messages returned by nodes when an eth_getLogs request covers too many blocks or logs
+var tooManyResultsMessages = []string{
+	"query returned more than", // query returned more than 10000 results
+	"log response size exceeded",
+	"exceed maximum block range",
+	"block range is too wide",
+	"block range too large",
+	"range is too large",
+	"requested too many blocks",
+	"query timeout exceeded",
+}
+
+func isTooManyResultsErr(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, m := range tooManyResultsMessages {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+logPager iterates over the logs matching query in the block range [next, end],
+requesting at most window blocks per eth_getLogs call.
+The window is halved when the node rejects a request due to too many results, and doubled after each successful request.
+*/
+type logPager struct {
+	backend Backend
+	query   ethereum.FilterQuery
+
+	next      uint64 // the first block which has not been queried
+	end       uint64 // the last block to query (inclusive)
+	window    uint64
+	maxWindow uint64 // the window is grown back up to it after successful requests
+	buffer    []types.Log
+}
+
+func newLogPager(backend Backend, query ethereum.FilterQuery, fromBlock uint64, toBlock uint64) *logPager {
+	window := QueryWindow
+	if window == 0 {
+		window = 1
+	}
+	return &logPager{
+		backend:   backend,
+		query:     query,
+		next:      fromBlock,
+		end:       toBlock,
+		window:    window,
+		maxWindow: window,
+	}
+}
+
+/**
+Returns the next log. ok is false if there is no more log or there is an error.
+*/
+func (p *logPager) nextLog(ctx context.Context) (log types.Log, ok bool, err error) {
+	for len(p.buffer) == 0 {
+		if p.next > p.end {
+			return log, false, nil
+		}
+		to := p.next + p.window - 1
+		if to > p.end || to < p.next {
+			to = p.end
+		}
+		query := p.query
+		query.FromBlock = new(big.Int).SetUint64(p.next)
+		query.ToBlock = new(big.Int).SetUint64(to)
+		logs, err := p.backend.FilterLogs(ctx, query)
+		if err != nil {
+			if isTooManyResultsErr(err) && p.window > 1 {
+				// shrink the window and retry
+				p.window /= 2
+				continue
+			}
+			return log, false, err
+		}
+		p.buffer = logs
+		p.next = to + 1
+		// grow the window back, so that a dense range does not slow down the rest of the query
+		if p.window < p.maxWindow {
+			p.window *= 2
+			if p.window > p.maxWindow || p.window == 0 {
+				p.window = p.maxWindow
+			}
+		}
+	}
+	log = p.buffer[0]
+	p.buffer = p.buffer[1:]
+	return log, true, nil
+}
+
+// addressTopics converts addresses to topics of indexed address arguments. Empty address matches any.
+func addressTopics(addresses ...Address) (topics []common.Hash, err error) {
+	for _, address := range addresses {
+		if address == "" {
+			return nil, nil
+		}
+		if !address.IsValid() {
+			return nil, InvalidAddressError
+		}
+		topics = append(topics, common.BytesToHash(address.address().Bytes()))
+	}
+	return topics, nil
+}
+
+// TransferIterator iterates over historical Transfer events returned by TFC.QueryTransfers
+type TransferIterator struct {
+	ctx   context.Context
+	tfc   *TFC
+	pager *logPager
+
+	event *TransferEvent
+	err   error
+}
+
+/**
+Advances the iterator to the next event, returning whether there is one.
+If false is returned, Error() can be checked to see whether the iteration stops due to an error.
+*/
+func (it *TransferIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	log, ok, err := it.pager.nextLog(it.ctx)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !ok {
+		return false
+	}
+	e, err := it.tfc.contract.ParseTransfer(log)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.event = &TransferEvent{
+		EventMeta: newEventMeta(log),
+		From:      Address(e.From.Hex()),
+		To:        Address(e.To.Hex()),
+		Value:     e.Value,
+	}
+	return true
+}
+
+// Event returns the current event
+func (it *TransferIterator) Event() *TransferEvent {
+	return it.event
+}
+
+// Error returns the error which stops the iteration
+func (it *TransferIterator) Error() error {
+	return it.err
+}
+
+/**
+Query historical Transfer events from "from" address to "to" address in the block range [fromBlock, toBlock].
+Empty from or to matches any address.
+
+The block range is requested page by page (see QueryWindow) when iterating over the returned iterator.
+*/
+func (tfc *TFC) QueryTransfers(ctx context.Context, from Address, to Address, fromBlock uint64, toBlock uint64) (it *TransferIterator, err error) {
+	if fromBlock > toBlock {
+		return nil, InvalidBlockRangeErr
+	}
+	fromTopics, err := addressTopics(from)
+	if err != nil {
+		return nil, err
+	}
+	toTopics, err := addressTopics(to)
+	if err != nil {
+		return nil, err
+	}
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{tfc.address.address()},
+		Topics:    [][]common.Hash{{tfcTokenABI.Events["Transfer"].ID}, fromTopics, toTopics},
+	}
+	return &TransferIterator{
+		ctx:   ctx,
+		tfc:   tfc,
+		pager: newLogPager(tfc.backend, query, fromBlock, toBlock),
+	}, nil
+}
+
+// ClaimIterator iterates over historical ClaimTFC events returned by Manager.QueryClaims
+type ClaimIterator struct {
+	ctx     context.Context
+	manager *Manager
+	pager   *logPager
+
+	event *ClaimEvent
+	err   error
+}
+
+/**
+Advances the iterator to the next event, returning whether there is one.
+If false is returned, Error() can be checked to see whether the iteration stops due to an error.
+*/
+func (it *ClaimIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	log, ok, err := it.pager.nextLog(it.ctx)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if !ok {
+		return false
+	}
+	e, err := it.manager.contract.ParseClaimTFC(log)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.event = &ClaimEvent{
+		EventMeta: newEventMeta(log),
+		Recipient: Address(e.Recipient.Hex()),
+		Amount:    e.Amount,
+		Nonce:     e.Nonce,
+		Signature: e.Sig,
+	}
+	return true
+}
+
+// Event returns the current event
+func (it *ClaimIterator) Event() *ClaimEvent {
+	return it.event
+}
+
+// Error returns the error which stops the iteration
+func (it *ClaimIterator) Error() error {
+	return it.err
+}
+
+/**
+Query historical ClaimTFC events of the recipient in the block range [fromBlock, toBlock].
+Empty recipient matches any address.
+
+The block range is requested page by page (see QueryWindow) when iterating over the returned iterator.
+*/
+func (manager *Manager) QueryClaims(ctx context.Context, recipient Address, fromBlock uint64, toBlock uint64) (it *ClaimIterator, err error) {
+	if fromBlock > toBlock {
+		return nil, InvalidBlockRangeErr
+	}
+	recipientTopics, err := addressTopics(recipient)
+	if err != nil {
+		return nil, err
+	}
+	query := ethereum.FilterQuery{
+		Addresses: []common.Address{manager.address},
+		Topics:    [][]common.Hash{{tfcManagerABI.Events["ClaimTFC"].ID}, recipientTopics},
+	}
+	return &ClaimIterator{
+		ctx:     ctx,
+		manager: manager,
+		pager:   newLogPager(manager.backend, query, fromBlock, toBlock),
+	}, nil
+}
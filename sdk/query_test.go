@@ -0,0 +1,212 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+)
+
+// rangeLimitedBackend rejects log queries covering more than maxRange blocks, like public nodes do
+type rangeLimitedBackend struct {
+	*MockBackend
+	maxRange uint64
+	requests int
+}
+
+func (b *rangeLimitedBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	b.requests++
+	if query.ToBlock.Uint64()-query.FromBlock.Uint64()+1 > b.maxRange {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	return b.MockBackend.FilterLogs(ctx, query)
+}
+
+// denseBackend rejects log queries covering more than one block below denseUntil, and records the requested ranges
+type denseBackend struct {
+	*MockBackend
+	denseUntil uint64
+	ranges     []uint64
+	err        error // returned for every request if not nil
+}
+
+func (b *denseBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	from, to := query.FromBlock.Uint64(), query.ToBlock.Uint64()
+	if from < b.denseUntil && to > from {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+	b.ranges = append(b.ranges, to-from+1)
+	return b.MockBackend.FilterLogs(ctx, query)
+}
+
+func TestLogPager_window(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	defaultWindow := QueryWindow
+	QueryWindow = 8
+	defer func() { QueryWindow = defaultWindow }()
+
+	// the window shrinks in the dense range and grows back after it
+	backend := &denseBackend{MockBackend: mockEth.Backend, denseUntil: 4}
+	pager := newLogPager(backend, ethereum.FilterQuery{}, 0, 100)
+	for {
+		_, ok, err := pager.nextLog(context.Background())
+		checkError(t, err)
+		if !ok {
+			break
+		}
+	}
+	full := 0
+	for _, r := range backend.ranges {
+		if r > QueryWindow {
+			t.Fatal("window exceeds QueryWindow", backend.ranges)
+		}
+		if r == QueryWindow {
+			full++
+		}
+	}
+	if backend.ranges[0] != 1 || full < 10 {
+		t.Fatal("window should grow back after the dense range, got", backend.ranges)
+	}
+
+	// unrelated errors are returned instead of retried
+	backend = &denseBackend{MockBackend: mockEth.Backend, err: errors.New("rate limited: more than 10 requests per second")}
+	pager = newLogPager(backend, ethereum.FilterQuery{}, 0, 100)
+	if _, _, err := pager.nextLog(context.Background()); err != backend.err || pager.window != QueryWindow {
+		t.Fatal("unrelated error should be returned, got", err, pager.window)
+	}
+}
+
+func TestTFC_QueryTransfers(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	defaultWindow := QueryWindow
+	QueryWindow = 16
+	defer func() { QueryWindow = defaultWindow }()
+
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), admin)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	// one mint per block, with empty blocks in between
+	var minted []*big.Int
+	for i := 1; i <= 30; i++ {
+		amount := big.NewInt(int64(i))
+		to := user.Address()
+		if i%3 == 0 {
+			to = admin.Address()
+		} else {
+			minted = append(minted, amount)
+		}
+		checkError(t, tfc.MintSync(context.Background(), to, amount, admin))
+		mockEth.Backend.Commit()
+	}
+	head, err := mockEth.Backend.HeaderByNumber(context.Background(), nil)
+	checkError(t, err)
+
+	backend := &rangeLimitedBackend{MockBackend: mockEth.Backend, maxRange: 5}
+	limitedTFC, err := NewTFC(backend, address)
+	checkError(t, err)
+	it, err := limitedTFC.QueryTransfers(context.Background(), "", user.Address(), 0, head.Number.Uint64())
+	checkError(t, err)
+	var lastBlock uint64
+	count := 0
+	for it.Next() {
+		event := it.Event()
+		if event.To != user.Address() || event.Value.Cmp(minted[count]) != 0 {
+			t.Fatal("transfer event is incorrect", event)
+		}
+		if event.BlockNumber <= lastBlock {
+			t.Fatal("transfer events are not in order")
+		}
+		lastBlock = event.BlockNumber
+		count++
+	}
+	checkError(t, it.Error())
+	if count != len(minted) {
+		t.Fatal("expect", len(minted), "transfers, got", count)
+	}
+	if backend.requests <= int(head.Number.Uint64()/5) {
+		t.Fatal("block range is not paginated")
+	}
+
+	// sub range
+	it, err = tfc.QueryTransfers(context.Background(), Address(""), admin.Address(), lastBlock-6, lastBlock)
+	checkError(t, err)
+	count = 0
+	for it.Next() {
+		count++
+	}
+	checkError(t, it.Error())
+	if count != 1 {
+		t.Fatal("expect 1 transfer in sub range, got", count)
+	}
+
+	_, err = tfc.QueryTransfers(context.Background(), "", "", 10, 9)
+	if err != InvalidBlockRangeErr {
+		t.Fatal("invalid block range should be rejected")
+	}
+}
+
+func TestManager_QueryClaims(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	defaultWindow := QueryWindow
+	QueryWindow = 4
+	defer func() { QueryWindow = defaultWindow }()
+
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[2]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployManagerSync(context.Background(), admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+
+	for i := int64(0); i < 5; i++ {
+		nonce := big.NewInt(i)
+		sig, err := manager.SignTFCClaim(user.Address(), big.NewInt(i+1), nonce, admin)
+		checkError(t, err)
+		checkError(t, manager.ClaimTFCSync(context.Background(), big.NewInt(i+1), nonce, sig, user))
+		mockEth.Backend.Commit()
+		mockEth.Backend.Commit()
+	}
+	head, err := mockEth.Backend.HeaderByNumber(context.Background(), nil)
+	checkError(t, err)
+
+	it, err := manager.QueryClaims(context.Background(), user.Address(), 0, head.Number.Uint64())
+	checkError(t, err)
+	var nonces []int64
+	for it.Next() {
+		event := it.Event()
+		if event.Recipient != user.Address() || event.Amount.Int64() != event.Nonce.Int64()+1 {
+			t.Fatal("claim event is incorrect", event)
+		}
+		nonces = append(nonces, event.Nonce.Int64())
+	}
+	checkError(t, it.Error())
+	if len(nonces) != 5 {
+		t.Fatal("expect 5 claims, got", len(nonces))
+	}
+
+	it, err = manager.QueryClaims(context.Background(), admin.Address(), 0, head.Number.Uint64())
+	checkError(t, err)
+	if it.Next() {
+		t.Fatal("claims of other recipients should not be returned")
+	}
+	checkError(t, it.Error())
+}
@@ -0,0 +1,119 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	solsha3 "github.com/offchainlabs/go-solidity-sha3"
+	"math"
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// QuoteValidity is how long a Quote of EstimateTFCExchangeFee is valid
+var QuoteValidity = 10 * time.Minute
+
+// QuoteValidityBlocks is the number of blocks after which a Quote of EstimateTFCExchangeFee expires
+var QuoteValidityBlocks uint64 = 50
+
+/**
+Quote is the fee of a bridge exchange quoted by EstimateTFCExchangeFee, signed by the bridge account.
+The deposit of the exchange should be at least RequiredTransferAmount,
+and the quote expires after block ExpiryBlock or time ExpiryTime (unix seconds), whichever comes first.
+The signature binds all the fields to the TFC contract, so that the quote cannot be altered or used for another contract.
+*/
+type Quote struct {
+	ID                     string        `json:"id"`
+	Recipient              Address       `json:"recipient"`
+	Amount                 *big.Int      `json:"amount"`
+	TransactionFeeRate     float64       `json:"transactionFeeRate"`
+	EstimatedGas           uint64        `json:"estimatedGas"`
+	GasPrice               *big.Int      `json:"gasPrice"` // worst-case price per gas
+	RequiredTransferAmount *big.Int      `json:"requiredTransferAmount"`
+	ExpiryBlock            uint64        `json:"expiryBlock"`
+	ExpiryTime             int64         `json:"expiryTime"`
+	Signature              hexutil.Bytes `json:"signature"`
+}
+
+// Expired returns true if the quote is expired at the block number and time
+func (quote *Quote) Expired(blockNumber uint64, now time.Time) bool {
+	return blockNumber > quote.ExpiryBlock || now.Unix() > quote.ExpiryTime
+}
+
+// ledgerKey returns the key of the quote in the DepositLedger, which is claimed when the quote is used
+func (quote *Quote) ledgerKey() Hash {
+	return Hash(crypto.Keccak256Hash([]byte("quote:"), []byte(quote.ID)).Hex())
+}
+
+// hash returns the hash of the quote for the TFC contract on the chain, which is signed as personal message.
+// All the fields are packed with fixed size, so that bytes cannot be shifted from one field to another:
+// the ID is packed as its keccak256 hash and the transaction fee rate as the IEEE 754 bits of the float.
+func (quote *Quote) hash(chainID *big.Int, tfcAddress Address) []byte {
+	return solsha3.SoliditySHA3(
+		[]string{"uint256", "address", "bytes32", "address", "uint256", "uint256", "uint256", "uint256", "uint256", "uint256", "uint256"},
+		[]interface{}{
+			chainID.String(),
+			tfcAddress.address().Hex(),
+			crypto.Keccak256([]byte(quote.ID)),
+			quote.Recipient.address().Hex(),
+			quote.Amount.String(),
+			strconv.FormatUint(math.Float64bits(quote.TransactionFeeRate), 10),
+			strconv.FormatUint(quote.EstimatedGas, 10),
+			quote.GasPrice.String(),
+			quote.RequiredTransferAmount.String(),
+			strconv.FormatUint(quote.ExpiryBlock, 10),
+			strconv.FormatInt(quote.ExpiryTime, 10),
+		},
+	)
+}
+
+// signQuote sets the expiry of the quote and signs it with the Signer of the bridge account
+func (tfc *TFC) signQuote(ctx context.Context, quote *Quote, bridgeAccount *Account) error {
+	chainID, err := tfc.chainID(ctx)
+	if err != nil {
+		return err
+	}
+	head, err := tfc.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	quote.ExpiryBlock = head.Number.Uint64() + QuoteValidityBlocks
+	quote.ExpiryTime = time.Now().Add(QuoteValidity).Unix()
+	quote.Signature, err = bridgeAccount.signer.SignMessage(ctx, quote.hash(chainID, tfc.address))
+	return err
+}
+
+/**
+Verify that the quote is signed by the bridge account for this TFC contract and is not expired.
+InvalidQuoteErr is returned if the quote is altered or signed by another account, and QuoteExpiredErr if it is expired.
+*/
+func (tfc *TFC) VerifyQuote(ctx context.Context, quote *Quote, bridge Address) error {
+	if err := tfc.verifyQuoteSignature(ctx, quote, bridge); err != nil {
+		return err
+	}
+	head, err := tfc.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if quote.Expired(head.Number.Uint64(), time.Now()) {
+		return QuoteExpiredErr
+	}
+	return nil
+}
+
+// verifyQuoteSignature verifies that the quote is signed by the bridge account for this TFC contract, regardless of its expiry
+func (tfc *TFC) verifyQuoteSignature(ctx context.Context, quote *Quote, bridge Address) error {
+	if quote == nil || quote.Amount == nil || quote.GasPrice == nil || quote.RequiredTransferAmount == nil || len(quote.Signature) != 65 {
+		return InvalidQuoteErr
+	}
+	chainID, err := tfc.chainID(ctx)
+	if err != nil {
+		return err
+	}
+	signer, err := recoverPersonalSigner(quote.hash(chainID, tfc.address), quote.Signature)
+	if err != nil || signer != bridge.address() {
+		return InvalidQuoteErr
+	}
+	return nil
+}
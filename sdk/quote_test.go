@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestTFC_VerifyQuote(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	recipient := PredefinedAccounts[1].Address()
+
+	quote, err := tfc.EstimateTFCExchangeFee(ctx, recipient, big.NewInt(100), bridge, 0, 0.1)
+	checkError(t, err)
+	checkError(t, tfc.VerifyQuote(ctx, quote, bridge.Address()))
+	// the quote survives a round trip to the client
+	data, err := json.Marshal(quote)
+	checkError(t, err)
+	var received Quote
+	checkError(t, json.Unmarshal(data, &received))
+	checkError(t, tfc.VerifyQuote(ctx, &received, bridge.Address()))
+
+	// altered quotes are rejected
+	altered := received
+	altered.Amount = big.NewInt(1000)
+	if err := tfc.VerifyQuote(ctx, &altered, bridge.Address()); err != InvalidQuoteErr {
+		t.Fatal("expect InvalidQuoteErr, got", err)
+	}
+	altered = received
+	altered.RequiredTransferAmount = new(big.Int).Sub(received.RequiredTransferAmount, big.NewInt(1))
+	if err := tfc.VerifyQuote(ctx, &altered, bridge.Address()); err != InvalidQuoteErr {
+		t.Fatal("expect InvalidQuoteErr, got", err)
+	}
+	altered = received
+	altered.ExpiryTime += 3600
+	if err := tfc.VerifyQuote(ctx, &altered, bridge.Address()); err != InvalidQuoteErr {
+		t.Fatal("expect InvalidQuoteErr, got", err)
+	}
+	if err := tfc.VerifyQuote(ctx, &received, PredefinedAccounts[2].Address()); err != InvalidQuoteErr {
+		t.Fatal("quote signed by another account should be rejected, got", err)
+	}
+	otherAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	other, err := sdk.TFC(otherAddress)
+	checkError(t, err)
+	if err := other.VerifyQuote(ctx, &received, bridge.Address()); err != InvalidQuoteErr {
+		t.Fatal("quote of another contract should be rejected, got", err)
+	}
+	if _, err := tfc.SendMintTx(ctx, &altered, "0x01", bridge, received.RequiredTransferAmount); err != InvalidQuoteErr {
+		t.Fatal("expect InvalidQuoteErr, got", err)
+	}
+	balance, err := tfc.BalanceOf(recipient)
+	checkError(t, err)
+	if balance.Sign() != 0 {
+		t.Fatal("altered quote should not be minted")
+	}
+}
+
+func TestTFC_VerifyQuote_expired(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	recipient := PredefinedAccounts[1].Address()
+
+	// expired by time
+	QuoteValidity = -time.Second
+	quote, err := tfc.EstimateTFCExchangeFee(ctx, recipient, big.NewInt(100), bridge, 0, 0.1)
+	QuoteValidity = 10 * time.Minute
+	checkError(t, err)
+	if _, err := tfc.SendMintTx(ctx, quote, "0x01", bridge, quote.RequiredTransferAmount); err != QuoteExpiredErr {
+		t.Fatal("expect QuoteExpiredErr, got", err)
+	}
+
+	// expired by blocks
+	QuoteValidityBlocks = 0
+	defer func() {
+		QuoteValidityBlocks = 50
+	}()
+	quote, err = tfc.EstimateTFCExchangeFee(ctx, recipient, big.NewInt(100), bridge, 0, 0.1)
+	checkError(t, err)
+	checkError(t, tfc.VerifyQuote(ctx, quote, bridge.Address()))
+	depositTo(t, mockEth.Backend, PredefinedAccounts[1], PredefinedAccounts[2].Address(), big.NewInt(1))
+	if _, err := tfc.SendMintTransaction(ctx, quote, "0x01", bridge, quote.RequiredTransferAmount); err != QuoteExpiredErr {
+		t.Fatal("expect QuoteExpiredErr, got", err)
+	}
+}
+
+func TestTFC_VerifyQuote_shifted(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+
+	// the low byte of the amount is '1', which can be shifted into the transaction fee rate
+	quote := &Quote{
+		ID:                     "quote-1",
+		Recipient:              PredefinedAccounts[1].Address(),
+		Amount:                 big.NewInt(0x1231),
+		TransactionFeeRate:     0.5,
+		EstimatedGas:           21000,
+		GasPrice:               big.NewInt(1),
+		RequiredTransferAmount: big.NewInt(1000),
+	}
+	checkError(t, tfc.signQuote(ctx, quote, bridge))
+	checkError(t, tfc.VerifyQuote(ctx, quote, bridge.Address()))
+
+	// shift one byte from the end of each variable-length field into the next field
+	recipient := PredefinedAccounts[1].address.Bytes()
+	shifted := *quote
+	shifted.ID = "quote-"
+	shifted.Recipient = Address(common.BytesToAddress(append([]byte("1"), recipient[:19]...)).Hex())
+	shifted.Amount = new(big.Int).Or(new(big.Int).Lsh(big.NewInt(int64(recipient[19])), 248), big.NewInt(0x12))
+	shifted.TransactionFeeRate = 10.5
+	if err := tfc.VerifyQuote(ctx, &shifted, bridge.Address()); err != InvalidQuoteErr {
+		t.Fatal("expect InvalidQuoteErr, got", err)
+	}
+	if shifted.ledgerKey() == quote.ledgerKey() {
+		t.Fatal("shifted quote should not share the ledger key")
+	}
+}
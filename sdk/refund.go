@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"context"
+	"github.com/Troublor/jasmine-eth-go/token"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"math/big"
+	"strings"
+)
+
+/**
+RefundPolicy decides whether and when a bridge deposit is refunded (see RefundDeposit).
+The zero value refunds any deposit without confirmation requirement as long as the refund covers its own gas.
+*/
+type RefundPolicy struct {
+	// the number of confirmations required for the deposit transaction before it is refunded
+	DepositConfirmationRequirement int
+	// refunds (net of gas) less than MinRefundAmount are not sent, nil means any positive amount is refunded.
+	// It is in the currency of the deposit, i.e. wei or the smallest unit of the ERC-20 token.
+	MinRefundAmount *big.Int
+}
+
+/**
+Refund the deposit transaction to its sender, e.g. if the deposit is below the quoted amount or the mint transaction cannot be sent.
+The deposit is refunded in its currency, i.e. ETH or the ERC-20 token (see CheckFeeDeposit),
+and the gas of the refund transaction (at the worst-case price per gas, see FeeStrategy) is deducted from the refunded amount.
+The gas of token refunds is converted to the token by the PriceOracle of the token.
+The refund is recorded in the DepositLedger of the provider against the deposit,
+so that the deposit can never be refunded twice or minted after it is refunded (DepositUsedErr is returned), and vice versa.
+InvalidDepositErr is returned if the deposit transaction is not sent to the bridge account,
+and RefundTooSmallErr if the refund does not cover its gas or is less than the MinRefundAmount of the policy.
+The refund transaction is recorded before it is broadcast, and if sending it fails after it may have been broadcast,
+the deposit stays claimed with the refund transaction recorded.
+*/
+func (tfc *TFC) RefundDeposit(ctx context.Context, depositTransactionHash string, bridgeAccount *Account, policy RefundPolicy) (refund *PendingTx, refundAmount *big.Int, err error) {
+	deposit, err := tfc.CheckFeeDeposit(ctx, depositTransactionHash, bridgeAccount.Address(), policy.DepositConfirmationRequirement)
+	if err != nil {
+		return nil, nil, err
+	}
+	refund, err = tfc.sendForDeposit(ctx, []Hash{Hash(depositTransactionHash)}, func(broadcast func(tx *types.Transaction) error) (*PendingTx, error) {
+		var pending *PendingTx
+		pending, refundAmount, err = tfc.refund(ctx, deposit, bridgeAccount, policy, broadcast)
+		return pending, err
+	})
+	if err != nil {
+		return refund, nil, err
+	}
+	return refund, refundAmount, nil
+}
+
+// refund sends the deposit amount net of gas from the bridge account back to the sender of the deposit, with broadcast of sendForDeposit
+func (tfc *TFC) refund(ctx context.Context, deposit *FeeDeposit, bridgeAccount *Account, policy RefundPolicy, broadcast func(tx *types.Transaction) error) (refund *PendingTx, refundAmount *big.Int, err error) {
+	fees, err := tfc.suggestFees(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	to := deposit.Sender.address()
+	msg := ethereum.CallMsg{From: bridgeAccount.address, To: &to, Value: deposit.Amount}
+	var tokenContract *token.TFCToken
+	if deposit.Token != "" {
+		// refund the token with an ERC-20 transfer
+		tokenAddress := deposit.Token.address()
+		tokenContract, err = token.NewTFCToken(tokenAddress, tfc.backend)
+		if err != nil {
+			return nil, nil, err
+		}
+		parsedABI, err := abi.JSON(strings.NewReader(token.TFCTokenABI))
+		if err != nil {
+			return nil, nil, err
+		}
+		input, err := parsedABI.Pack("transfer", to, deposit.TokenAmount)
+		if err != nil {
+			return nil, nil, err
+		}
+		msg = ethereum.CallMsg{From: bridgeAccount.address, To: &tokenAddress, Value: big.NewInt(0), Data: input}
+	}
+	gas, err := tfc.backend.EstimateGas(ctx, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+	if tokenContract != nil {
+		// the refund amount in the calldata may have more non-zero bytes than the deposit amount
+		gas += 32 * (params.TxDataNonZeroGasEIP2028 - params.TxDataZeroGas)
+	}
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(gas), fees.MaxGasPrice())
+	if tokenContract == nil {
+		refundAmount = new(big.Int).Sub(deposit.Amount, fee)
+	} else {
+		oracle := tfc.feeTokenOracle(deposit.Token.address())
+		if oracle == nil {
+			return nil, nil, InvalidDepositErr
+		}
+		price, err := oracle.Price(ctx, deposit.Token)
+		if err != nil {
+			return nil, nil, err
+		}
+		if price.Sign() <= 0 {
+			return nil, nil, InvalidTokenPriceErr
+		}
+		refundAmount = new(big.Int).Sub(deposit.TokenAmount, toTokenUnits(fee, price))
+	}
+	if refundAmount.Sign() <= 0 || (policy.MinRefundAmount != nil && refundAmount.Cmp(policy.MinRefundAmount) < 0) {
+		return nil, nil, RefundTooSmallErr
+	}
+	chainID, err := tfc.chainID(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	refund, err = tfc.transactWithFees(ctx, bridgeAccount, fees, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		var signed *types.Transaction
+		if tokenContract != nil {
+			auth.GasLimit = gas
+			auth.NoSend = true
+			signed, err = tokenContract.Transfer(auth, to, refundAmount)
+		} else {
+			signed, err = auth.Signer(auth.From, transferTx(chainID, auth, to, refundAmount, gas))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := broadcast(signed); err != nil {
+			return nil, err
+		}
+		return signed, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	// the recorded refund transaction must not be replaced
+	refund.SetBumpPolicy(nil)
+	return refund, refundAmount, nil
+}
@@ -0,0 +1,92 @@
+package sdk
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestTFC_RefundDeposit(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	backend := mockEth.Backend
+	crashing := &crashingBackend{MockBackend: backend}
+	sdk := NewSDKWithBackend(crashing)
+	ctx := context.Background()
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+	oneEther := big.NewInt(1000000000000000000)
+
+	// deposits not sent to the bridge account cannot be refunded
+	otherHash := depositTo(t, backend, user, PredefinedAccounts[2].Address(), oneEther)
+	if _, _, err := tfc.RefundDeposit(ctx, otherHash, bridge, RefundPolicy{}); err != InvalidDepositErr {
+		t.Fatal("expect InvalidDepositErr, got", err)
+	}
+	// dust does not cover the gas of its refund
+	dustHash := depositTo(t, backend, user, bridge.Address(), big.NewInt(1000))
+	if _, _, err := tfc.RefundDeposit(ctx, dustHash, bridge, RefundPolicy{}); err != RefundTooSmallErr {
+		t.Fatal("expect RefundTooSmallErr, got", err)
+	}
+	if claimed, _, err := sdk.DepositLedger().Lookup(Hash(dustHash)); err != nil || claimed {
+		t.Fatal("claim should be released")
+	}
+
+	depositHash := depositTo(t, backend, user, bridge.Address(), oneEther)
+	if _, _, err := tfc.RefundDeposit(ctx, depositHash, bridge, RefundPolicy{DepositConfirmationRequirement: 1}); err != UnconfirmedTransactionErr {
+		t.Fatal("expect UnconfirmedTransactionErr, got", err)
+	}
+	if _, _, err := tfc.RefundDeposit(ctx, depositHash, bridge, RefundPolicy{MinRefundAmount: oneEther}); err != RefundTooSmallErr {
+		t.Fatal("expect RefundTooSmallErr, got", err)
+	}
+	before, err := backend.BalanceAt(ctx, user.address, nil)
+	checkError(t, err)
+	refund, amount, err := tfc.RefundDeposit(ctx, depositHash, bridge, RefundPolicy{})
+	checkError(t, err)
+	if amount.Sign() <= 0 || amount.Cmp(oneEther) >= 0 {
+		t.Fatal("refund should be net of gas, got", amount)
+	}
+	_, err = refund.Wait(ctx, 0)
+	checkError(t, err)
+	after, err := backend.BalanceAt(ctx, user.address, nil)
+	checkError(t, err)
+	if new(big.Int).Sub(after, before).Cmp(amount) != 0 {
+		t.Fatal("sender should receive the refund")
+	}
+	if _, recorded, err := sdk.DepositLedger().Lookup(Hash(depositHash)); err != nil || recorded != refund.Hash() {
+		t.Fatal("refund is not recorded")
+	}
+
+	// the refunded deposit can neither be refunded nor minted again
+	if _, _, err := tfc.RefundDeposit(ctx, depositHash, bridge, RefundPolicy{}); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+	if _, _, err := tfc.BridgeTFCExchangeAsync(ctx, depositHash, big.NewInt(100), bridge, 0); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+	// and the minted deposit cannot be refunded
+	mintedHash := depositTo(t, backend, user, bridge.Address(), oneEther)
+	_, _, err = tfc.BridgeTFCExchangeAsync(ctx, mintedHash, big.NewInt(100), bridge, 0)
+	checkError(t, err)
+	if _, _, err := tfc.RefundDeposit(ctx, mintedHash, bridge, RefundPolicy{}); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+
+	// the deposit stays claimed if the refund may have been broadcast
+	crashedHash := depositTo(t, backend, user, bridge.Address(), oneEther)
+	crashing.crashed = true
+	if _, _, err := tfc.RefundDeposit(ctx, crashedHash, bridge, RefundPolicy{}); err == nil || err.Error() != "crashed" {
+		t.Fatal("expect the crash, got", err)
+	}
+	crashing.crashed = false
+	if claimed, recorded, err := sdk.DepositLedger().Lookup(Hash(crashedHash)); err != nil || !claimed || recorded == "" {
+		t.Fatal("deposit should stay claimed with the refund transaction recorded")
+	}
+	if _, _, err := tfc.RefundDeposit(ctx, crashedHash, bridge, RefundPolicy{}); err != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", err)
+	}
+}
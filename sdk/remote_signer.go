@@ -0,0 +1,145 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"math/big"
+)
+
+/**
+RemoteSigner signs via the JSON-RPC API (eth_signTransaction, eth_sign and eth_signTypedData_v4) of a remote signer, e.g. Clef or a node holding the key.
+The signed transactions returned by the remote signer are checked against the requested ones,
+and the signatures of messages and typed data are checked to be signed by the account.
+*/
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+}
+
+func NewRemoteSigner(client *rpc.Client, address Address) *RemoteSigner {
+	return &RemoteSigner{
+		client:  client,
+		address: address.address(),
+	}
+}
+
+/**
+Connects to the remote signer at endpoint, which signs for the account with address.
+*/
+func DialRemoteSigner(endpoint string, address Address) (signer *RemoteSigner, err error) {
+	if !address.IsValid() {
+		return nil, InvalidAddressError
+	}
+	client, err := rpc.Dial(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemoteSigner(client, address), nil
+}
+
+// remoteTxArgs is the transaction object of eth_signTransaction
+type remoteTxArgs struct {
+	From                 common.Address   `json:"from"`
+	To                   *common.Address  `json:"to,omitempty"`
+	Gas                  hexutil.Uint64   `json:"gas"`
+	GasPrice             *hexutil.Big     `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big     `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big     `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big     `json:"value"`
+	Nonce                hexutil.Uint64   `json:"nonce"`
+	Data                 hexutil.Bytes    `json:"data"`
+	AccessList           types.AccessList `json:"accessList,omitempty"`
+	ChainID              *hexutil.Big     `json:"chainId,omitempty"`
+}
+
+// remoteSignTxResult is the result of eth_signTransaction
+type remoteSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+func (s *RemoteSigner) Address() Address {
+	return Address(s.address.Hex())
+}
+
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (signed *types.Transaction, err error) {
+	args := remoteTxArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = tx.AccessList()
+	}
+	var result remoteSignTxResult
+	if err := s.client.CallContext(ctx, &result, "eth_signTransaction", args); err != nil {
+		return nil, err
+	}
+	signed = new(types.Transaction)
+	if err := signed.UnmarshalBinary(result.Raw); err != nil {
+		return nil, err
+	}
+	// the remote signer must sign exactly the requested transaction
+	signer := types.LatestSignerForChainID(chainID)
+	if signed.Type() != tx.Type() || signer.Hash(signed) != signer.Hash(tx) {
+		return nil, SignedTransactionMismatchErr
+	}
+	if from, err := types.Sender(signer, signed); err != nil || from != s.address {
+		return nil, SignedTransactionMismatchErr
+	}
+	return signed, nil
+}
+
+func (s *RemoteSigner) SignMessage(ctx context.Context, message []byte) (signature []byte, err error) {
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "eth_sign", s.address, hexutil.Bytes(message)); err != nil {
+		return nil, err
+	}
+	return s.checkSignature(result, func(signature []byte) (common.Address, error) {
+		return recoverPersonalSigner(message, signature)
+	})
+}
+
+func (s *RemoteSigner) SignTypedData(ctx context.Context, typedData *TypedData) (signature []byte, err error) {
+	var result hexutil.Bytes
+	if err := s.client.CallContext(ctx, &result, "eth_signTypedData_v4", s.address, typedData); err != nil {
+		return nil, err
+	}
+	return s.checkSignature(result, func(signature []byte) (common.Address, error) {
+		return recoverTypedDataSigner(typedData, signature)
+	})
+}
+
+// checkSignature normalizes V of the signature returned by the remote signer to 27/28 as signHash does,
+// and checks that the signature is signed by the account of the signer
+func (s *RemoteSigner) checkSignature(result []byte, recover func(signature []byte) (common.Address, error)) (signature []byte, err error) {
+	if len(result) != 65 {
+		return nil, InvalidSignatureErr
+	}
+	signature = common.CopyBytes(result)
+	switch signature[64] {
+	case 0, 1:
+		signature[64] += 27
+	case 27, 28:
+	default:
+		return nil, InvalidSignatureErr
+	}
+	signer, err := recover(signature)
+	if err != nil {
+		return nil, err
+	}
+	if signer != s.address {
+		return nil, SignerMismatchErr
+	}
+	return signature, nil
+}
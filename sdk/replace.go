@@ -0,0 +1,254 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sync"
+)
+
+// MinReplacementBump is the minimum gas price (or both fee caps of dynamic fee transactions) bump in percent for a node to accept a replacement transaction
+var MinReplacementBump = 10
+
+/**
+BumpPolicy makes the confirmation waiters replace the transaction with a higher gas price
+if it is not mined after a number of blocks.
+*/
+type BumpPolicy struct {
+	// Blocks is the number of new blocks without the transaction being mined before it is bumped
+	Blocks int
+	// Percent is the gas price bump of each replacement, at least MinReplacementBump
+	Percent int
+	// MaxGasPrice is the maximum gas price (or max fee per gas of dynamic fee transactions) of replacements, nil means no limit
+	MaxGasPrice *big.Int
+}
+
+type nonceKey struct {
+	from  common.Address
+	nonce uint64
+}
+
+/**
+replacementRegistry records the transactions sent with the same nonce by this provider,
+so that waiters of a transaction follow its replacements.
+*/
+type replacementRegistry struct {
+	lock    sync.Mutex
+	txs     map[nonceKey][]*types.Transaction
+	cancels map[common.Hash]bool
+	changed chan struct{} // closed and renewed when a replacement is added
+}
+
+func newReplacementRegistry() *replacementRegistry {
+	return &replacementRegistry{
+		txs:     make(map[nonceKey][]*types.Transaction),
+		cancels: make(map[common.Hash]bool),
+		changed: make(chan struct{}),
+	}
+}
+
+func (r *replacementRegistry) add(key nonceKey, original *types.Transaction, replacement *types.Transaction, cancel bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if len(r.txs[key]) == 0 {
+		r.txs[key] = []*types.Transaction{original}
+	}
+	r.txs[key] = append(r.txs[key], replacement)
+	if cancel {
+		r.cancels[replacement.Hash()] = true
+	}
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// get returns the transactions with the nonce, and a channel which is closed when there is a new replacement
+func (r *replacementRegistry) get(key nonceKey) (txs []*types.Transaction, changed chan struct{}) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return append([]*types.Transaction(nil), r.txs[key]...), r.changed
+}
+
+// lookup returns the transaction with the hash which has been replaced, nil if not found
+func (r *replacementRegistry) lookup(txHash common.Hash) *types.Transaction {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, txs := range r.txs {
+		for _, tx := range txs {
+			if tx.Hash() == txHash {
+				return tx
+			}
+		}
+	}
+	return nil
+}
+
+func (r *replacementRegistry) isCancel(txHash common.Hash) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.cancels[txHash]
+}
+
+func (r *replacementRegistry) remove(key nonceKey) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, tx := range r.txs[key] {
+		delete(r.cancels, tx.Hash())
+	}
+	delete(r.txs, key)
+}
+
+/**
+Replace the pending transaction with the same one (or a zero-value self-transfer if cancel is true) with higher fees.
+The replacement keeps the type of the transaction.
+The gas price (or both the max fee and the priority fee per gas of dynamic fee transactions) is bumped by bumpPercent
+(at least MinReplacementBump) over the latest replacement, or set to the suggested one if it is higher.
+*/
+func (p *provider) replaceTx(ctx context.Context, tx *types.Transaction, account *Account, bumpPercent int, maxGasPrice *big.Int, cancel bool) (replacement *PendingTx, err error) {
+	if bumpPercent < MinReplacementBump {
+		bumpPercent = MinReplacementBump
+	}
+	key := nonceKey{account.address, tx.Nonce()}
+	if txs, _ := p.replacements.get(key); len(txs) > 0 {
+		tx = txs[len(txs)-1]
+	}
+	_, isPending, err := p.backend.TransactionByHash(ctx, tx.Hash())
+	if err == ethereum.NotFound || (err == nil && !isPending) {
+		return nil, TransactionNotPendingErr
+	} else if err != nil {
+		return nil, err
+	}
+
+	fees := feesOf(tx)
+	var suggested *Fees
+	if fees.IsDynamic() {
+		suggested, err = DynamicFeeStrategy.Fees(ctx, p.backend)
+	} else {
+		suggested, err = LegacyFeeStrategy.Fees(ctx, p.backend)
+	}
+	if err != nil {
+		return nil, err
+	}
+	fees = &Fees{
+		GasPrice:  bumpFee(fees.GasPrice, suggested.GasPrice, bumpPercent),
+		GasFeeCap: bumpFee(fees.GasFeeCap, suggested.GasFeeCap, bumpPercent),
+		GasTipCap: bumpFee(fees.GasTipCap, suggested.GasTipCap, bumpPercent),
+	}
+	if maxGasPrice != nil && fees.MaxGasPrice().Cmp(maxGasPrice) > 0 {
+		return nil, GasPriceLimitErr
+	}
+
+	to, value, gas, data := tx.To(), tx.Value(), tx.Gas(), tx.Data()
+	if cancel {
+		to, value, gas, data = &account.address, big.NewInt(0), 21000, nil
+	}
+	var unsigned *types.Transaction
+	if fees.IsDynamic() {
+		unsigned = types.NewTx(&types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  fees.GasTipCap,
+			GasFeeCap:  fees.GasFeeCap,
+			Gas:        gas,
+			To:         to,
+			Value:      value,
+			Data:       data,
+			AccessList: tx.AccessList(),
+		})
+	} else {
+		unsigned = types.NewTx(&types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: fees.GasPrice,
+			Gas:      gas,
+			To:       to,
+			Value:    value,
+			Data:     data,
+		})
+	}
+	chainID, err := p.chainID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := account.signer.SignTx(ctx, unsigned, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.backend.SendTransaction(ctx, signed); err != nil {
+		return nil, err
+	}
+	p.replacements.add(key, tx, signed, cancel)
+	replacement = p.newPendingTx(signed, account)
+	replacement.cancel = cancel
+	return replacement, nil
+}
+
+// bumpFee returns ceil(fee * (100 + bumpPercent) / 100), or suggested if it is higher, nil if fee is nil
+func bumpFee(fee *big.Int, suggested *big.Int, bumpPercent int) *big.Int {
+	if fee == nil {
+		return nil
+	}
+	bumped := new(big.Int).Mul(fee, big.NewInt(int64(100+bumpPercent)))
+	bumped.Add(bumped, big.NewInt(99))
+	bumped.Div(bumped, big.NewInt(100))
+	if suggested != nil && suggested.Cmp(bumped) > 0 {
+		return suggested
+	}
+	return bumped
+}
+
+func (sdk *SDK) pendingTxOf(ctx context.Context, txHash Hash, account *Account) (tx *types.Transaction, err error) {
+	// the transaction may be replaced and no longer known by the node
+	if tx := sdk.replacements.lookup(common.HexToHash(string(txHash))); tx != nil {
+		signer, err := sdk.signer(ctx)
+		if err != nil {
+			return nil, err
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			return nil, err
+		}
+		if from != account.address {
+			return nil, NotTransactionSenderErr
+		}
+		return tx, nil
+	}
+	pending, err := sdk.LoadPendingTx(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	if pending.From().address() != account.address {
+		return nil, NotTransactionSenderErr
+	}
+	if pending.Status() != TxPending {
+		return nil, TransactionNotPendingErr
+	}
+	return pending.Transaction(), nil
+}
+
+/**
+Speed up a pending transaction of account by re-sending it with the same nonce and fees bumped by bumpPercent.
+The bump is at least MinReplacementBump, otherwise the node would reject the replacement.
+
+Waiters of the original transaction (e.g. Mint or PendingTx.Wait) follow the replacement, whichever of them is mined.
+*/
+func (sdk *SDK) SpeedUp(ctx context.Context, txHash Hash, account *Account, bumpPercent int) (replacement *PendingTx, err error) {
+	tx, err := sdk.pendingTxOf(ctx, txHash, account)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.replaceTx(ctx, tx, account, bumpPercent, nil, false)
+}
+
+/**
+Cancel a pending transaction of account by replacing it with a zero-value transfer to account itself with the same nonce.
+
+Waiters of the original transaction get TransactionCancelledErr if the cancellation is mined.
+*/
+func (sdk *SDK) Cancel(ctx context.Context, txHash Hash, account *Account) (replacement *PendingTx, err error) {
+	tx, err := sdk.pendingTxOf(ctx, txHash, account)
+	if err != nil {
+		return nil, err
+	}
+	return sdk.replaceTx(ctx, tx, account, MinReplacementBump, nil, true)
+}
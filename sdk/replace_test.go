@@ -0,0 +1,157 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type waitResult struct {
+	receipt *types.Receipt
+	err     error
+}
+
+func waitAsync(pending *PendingTx) chan waitResult {
+	resultCh := make(chan waitResult, 1)
+	go func() {
+		receipt, err := pending.Wait(context.Background(), 0)
+		resultCh <- waitResult{receipt, err}
+	}()
+	return resultCh
+}
+
+func nextWaitResult(t *testing.T, resultCh chan waitResult) waitResult {
+	select {
+	case result := <-resultCh:
+		return result
+	case <-time.After(3 * time.Second):
+		t.Fatal("transaction is not confirmed")
+	}
+	return waitResult{}
+}
+
+func deployStuckTFC(t *testing.T, mockEth *MockEthereum) (sdk *SDK, tfc *TFC) {
+	sdk = NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err = sdk.TFC(address)
+	checkError(t, err)
+	// transactions with the suggested priority fee (1 wei in simulated backend) are stuck
+	mockEth.Backend.SetMinGasPrice(big.NewInt(3))
+	return sdk, tfc
+}
+
+func TestSDK_SpeedUp(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk, tfc := deployStuckTFC(t, mockEth)
+
+	pending, err := tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+	checkError(t, err)
+	resultCh := waitAsync(pending)
+	original := pending.Hash()
+
+	// bump is at least MinReplacementBump, which is still not enough
+	replacement, err := sdk.SpeedUp(context.Background(), original, PredefinedAccounts[0], 1)
+	checkError(t, err)
+	if replacement.Nonce() != pending.Nonce() || replacement.GasTipCap().Cmp(big.NewInt(2)) != 0 {
+		t.Fatal("replacement should have the same nonce and bumped priority fee, got", replacement.GasTipCap())
+	}
+	if replacement.GasFeeCap().Cmp(pending.GasFeeCap()) <= 0 {
+		t.Fatal("replacement should have bumped max fee")
+	}
+	_, err = sdk.SpeedUp(context.Background(), replacement.Hash(), PredefinedAccounts[1], 10)
+	if err != NotTransactionSenderErr {
+		t.Fatal("expect NotTransactionSenderErr, got", err)
+	}
+
+	// bumped again over the latest replacement, although the original one is no longer known by the node
+	replacement, err = sdk.SpeedUp(context.Background(), original, PredefinedAccounts[0], 100)
+	checkError(t, err)
+	if replacement.GasTipCap().Cmp(big.NewInt(4)) != 0 {
+		t.Fatal("expect priority fee 4, got", replacement.GasTipCap())
+	}
+
+	result := nextWaitResult(t, resultCh)
+	checkError(t, result.err)
+	if pending.Hash() != replacement.Hash() || result.receipt.TxHash.Hex() != string(replacement.Hash()) {
+		t.Fatal("waiter does not follow the mined replacement")
+	}
+	balance, err := tfc.BalanceOf(PredefinedAccounts[1].Address())
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(100)) != 0 {
+		t.Fatal("replacement is not executed")
+	}
+
+	_, err = sdk.SpeedUp(context.Background(), replacement.Hash(), PredefinedAccounts[0], 10)
+	if err != TransactionNotPendingErr {
+		t.Fatal("expect TransactionNotPendingErr, got", err)
+	}
+}
+
+func TestSDK_Cancel(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk, tfc := deployStuckTFC(t, mockEth)
+	mockEth.Backend.SetMinGasPrice(big.NewInt(2))
+
+	pending, err := tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+	checkError(t, err)
+	doneCh, errCh := asyncDone(context.Background(), pending, nil)
+
+	cancellation, err := sdk.Cancel(context.Background(), pending.Hash(), PredefinedAccounts[0])
+	checkError(t, err)
+	select {
+	case <-doneCh:
+		t.Fatal("cancelled mint should not be done")
+	case err := <-errCh:
+		if err != TransactionCancelledErr {
+			t.Fatal("expect TransactionCancelledErr, got", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("cancellation is not confirmed")
+	}
+	_, err = cancellation.Wait(context.Background(), 0)
+	checkError(t, err)
+	totalSupply, err := tfc.TotalSupply()
+	checkError(t, err)
+	if totalSupply.Sign() != 0 {
+		t.Fatal("cancelled mint is executed")
+	}
+}
+
+func TestPendingTx_BumpPolicy(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk, tfc := deployStuckTFC(t, mockEth)
+	sdk.SetBumpPolicy(&BumpPolicy{Blocks: 2, Percent: 50})
+
+	pending, err := tfc.MintTx(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), PredefinedAccounts[0])
+	checkError(t, err)
+	resultCh := waitAsync(pending)
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case result := <-resultCh:
+			checkError(t, result.err)
+			if pending.GasTipCap().Cmp(big.NewInt(3)) < 0 {
+				t.Fatal("mined transaction should be bumped, got priority fee", pending.GasTipCap())
+			}
+			balance, err := tfc.BalanceOf(PredefinedAccounts[1].Address())
+			checkError(t, err)
+			if balance.Cmp(big.NewInt(100)) != 0 {
+				t.Fatal("bumped transaction is not executed")
+			}
+			return
+		case <-time.After(50 * time.Millisecond):
+			mockEth.Backend.Commit()
+		case <-timeout:
+			t.Fatal("transaction is not bumped")
+		}
+	}
+}
@@ -2,10 +2,10 @@ package sdk
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"github.com/Troublor/jasmine-eth-go/token"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"math/big"
 )
@@ -36,21 +36,22 @@ func NewSDKWithBackend(backend Backend) (sdk *SDK) {
 
 //setDefaultAccount sets the default Account to sign ethereum transactions by providing its privateKey
 func (sdk *SDK) SetDefaultAccount(privateKey string) (err error) {
-	acc := &Account{}
-	acc.privateKey, err = crypto.HexToECDSA(privateKey)
+	acc, err := retrieveAccount(privateKey)
 	if err != nil {
-		return InvalidPrivateKeyError
+		return err
 	}
-	var ok bool
-	acc.publicKey, ok = acc.privateKey.Public().(*ecdsa.PublicKey)
-	if !ok {
-		return InvalidPrivateKeyError
-	}
-	acc.address = crypto.PubkeyToAddress(*acc.publicKey)
 	sdk.account = acc
 	return nil
 }
 
+/**
+SetDefaultSigner sets the default Account to sign ethereum transactions by providing its Signer,
+so that the private key does not need to be in process memory.
+*/
+func (sdk *SDK) SetDefaultSigner(signer Signer) {
+	sdk.account = NewAccount(signer)
+}
+
 func (sdk *SDK) RetrieveAccount(privateKey string) (account *Account, err error) {
 	return retrieveAccount(privateKey)
 }
@@ -59,6 +60,35 @@ func (sdk *SDK) CreateAccount() (account *Account) {
 	return createAccount()
 }
 
+/**
+ImportAccount imports the account from Web3 Secret Storage JSON (i.e. a keystore file of geth) encrypted with passphrase.
+See also Account.ExportKeystore.
+*/
+func (sdk *SDK) ImportAccount(keyJSON []byte, passphrase string) (account *Account, err error) {
+	return importAccount(keyJSON, passphrase)
+}
+
+/**
+DeriveAccount derives the account with index from the BIP-39 mnemonic along the BIP-44 path m/44'/60'/0'/0/index.
+A new mnemonic can be generated by NewMnemonic.
+*/
+func (sdk *SDK) DeriveAccount(mnemonic string, index uint32) (account *Account, err error) {
+	return deriveAccount(mnemonic, index)
+}
+
+/**
+DeriveAccountXPub derives the extended public key of m/44'/60'/0'/0 from the BIP-39 mnemonic,
+whose child with index is the account derived by DeriveAccount with the same index.
+It is used to derive deposit addresses (see DepositAddresses) without the mnemonic.
+*/
+func (sdk *SDK) DeriveAccountXPub(mnemonic string) (xpub string, err error) {
+	key, err := deriveAccountXPub(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
 /**
 DefaultAccount returns the current default Account in sdk (can be set via SetDefaultAccount())
 */
@@ -76,38 +106,29 @@ func (sdk *SDK) DeployTFCSync(ctx context.Context, deployer *Account) (tfcAddres
 	}
 }
 
-func (sdk *SDK) DeployTFC(ctx context.Context, deployer *Account) (tfcAddressCh chan Address, errCh chan error) {
-	tfcAddressCh = make(chan Address, 1)
-	errCh = make(chan error, 1)
-	auth := bind.NewKeyedTransactor(deployer.privateKey)
-	nonce, err := sdk.backend.PendingNonceAt(context.Background(), deployer.address)
-	if err != nil {
-		errCh <- err
-		return tfcAddressCh, errCh
-	}
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)
-	auth.GasPrice, err = sdk.backend.SuggestGasPrice(context.Background())
+/**
+Deploy a TFC ERC20 contract, of which the deployer has all roles.
+The address of the contract is available once the returned transaction is mined.
+*/
+func (sdk *SDK) DeployTFCTx(ctx context.Context, deployer *Account) (tfcAddress Address, pending *PendingTx, err error) {
+	var address common.Address
+	pending, err = sdk.transact(ctx, deployer, func(auth *bind.TransactOpts) (tx *types.Transaction, err error) {
+		auth.Value = big.NewInt(0)
+		address, tx, _, err = token.DeployTFCToken(auth, sdk.backend, deployer.address, deployer.address)
+		return tx, err
+	})
 	if err != nil {
-		errCh <- err
-		return tfcAddressCh, errCh
-	}
-	_, tx, _, err := token.DeployTFCToken(auth, sdk.backend, deployer.address, deployer.address)
-	if err != nil {
-		errCh <- err
-		return tfcAddressCh, errCh
+		return "", nil, err
 	}
+	return Address(address.Hex()), pending, nil
+}
 
-	receiptCh, eCh := sdk.AsyncTransaction(ctx, tx.Hash(), ConfirmationRequirement)
-	go func() {
-		select {
-		case receipt := <-receiptCh:
-			tfcAddressCh <- Address(receipt.ContractAddress.Hex())
-		case err := <-eCh:
-			errCh <- err
-		}
-	}()
-	return tfcAddressCh, errCh
+/**
+DeployTFC is the same as DeployTFCTx, except that the contract address is fed to tfcAddressCh when the transaction is confirmed.
+*/
+func (sdk *SDK) DeployTFC(ctx context.Context, deployer *Account) (tfcAddressCh chan Address, errCh chan error) {
+	tfcAddress, pending, err := sdk.DeployTFCTx(ctx, deployer)
+	return asyncDeployed(ctx, tfcAddress, pending, err)
 }
 
 func (sdk *SDK) DeployManagerSync(ctx context.Context, deployer *Account) (managerAddress Address, err error) {
@@ -120,50 +141,67 @@ func (sdk *SDK) DeployManagerSync(ctx context.Context, deployer *Account) (manag
 	}
 }
 
-func (sdk *SDK) DeployManager(ctx context.Context, deployer *Account) (managerAddressCh chan Address, errCh chan error) {
-	managerAddressCh = make(chan Address, 1)
-	errCh = make(chan error, 1)
-	auth := bind.NewKeyedTransactor(deployer.privateKey)
-	nonce, err := sdk.backend.PendingNonceAt(context.Background(), deployer.address)
-	if err != nil {
-		errCh <- err
-		return managerAddressCh, errCh
-	}
-	auth.Nonce = big.NewInt(int64(nonce))
-	auth.Value = big.NewInt(0)
-	auth.GasPrice, err = sdk.backend.SuggestGasPrice(context.Background())
+/**
+Deploy a TFC Manager contract, which also deploys a TFC ERC20 contract (see Manager.TFCAddress).
+The deployer will be the signer of TFC claims.
+The address of the contract is available once the returned transaction is mined.
+*/
+func (sdk *SDK) DeployManagerTx(ctx context.Context, deployer *Account) (managerAddress Address, pending *PendingTx, err error) {
+	var address common.Address
+	pending, err = sdk.transact(ctx, deployer, func(auth *bind.TransactOpts) (tx *types.Transaction, err error) {
+		auth.Value = big.NewInt(0)
+		address, tx, _, err = token.DeployTFCManager(auth, sdk.backend)
+		return tx, err
+	})
 	if err != nil {
-		errCh <- err
-		return managerAddressCh, errCh
+		return "", nil, err
 	}
-	_, tx, _, err := token.DeployTFCManager(auth, sdk.backend)
+	return Address(address.Hex()), pending, nil
+}
+
+/**
+DeployManager is the same as DeployManagerTx, except that the contract address is fed to managerAddressCh when the transaction is confirmed.
+*/
+func (sdk *SDK) DeployManager(ctx context.Context, deployer *Account) (managerAddressCh chan Address, errCh chan error) {
+	managerAddress, pending, err := sdk.DeployManagerTx(ctx, deployer)
+	return asyncDeployed(ctx, managerAddress, pending, err)
+}
+
+func asyncDeployed(ctx context.Context, address Address, pending *PendingTx, err error) (addressCh chan Address, errCh chan error) {
+	addressCh = make(chan Address, 1)
+	errCh = make(chan error, 1)
 	if err != nil {
 		errCh <- err
-		return managerAddressCh, errCh
+		return addressCh, errCh
 	}
-
-	receiptCh, eCh := sdk.AsyncTransaction(ctx, tx.Hash(), ConfirmationRequirement)
 	go func() {
-		select {
-		case receipt := <-receiptCh:
-			managerAddressCh <- Address(receipt.ContractAddress.Hex())
-		case err := <-eCh:
+		_, err := pending.Wait(ctx, ConfirmationRequirement)
+		if err == nil {
+			err = pending.RevertError(ctx)
+		}
+		if err != nil {
 			errCh <- err
+			return
 		}
+		addressCh <- address
 	}()
-	return managerAddressCh, errCh
+	return addressCh, errCh
 }
 
 /**
 Creates a new TFC instance based on current sdk.
-This function is a wrapper of NewTFC()
+This function is a wrapper of NewTFC(), except that the TFC shares the transaction ConfirmationTracker with sdk.
 */
 func (sdk *SDK) TFC(tfcAddress Address) (tfc *TFC, err error) {
-	return NewTFC(sdk.backend, tfcAddress)
+	return newTFC(sdk.provider, tfcAddress)
 }
 
+/**
+Creates a new Manager instance based on current sdk.
+The Manager shares the transaction ConfirmationTracker with sdk.
+*/
 func (sdk *SDK) Manager(managerAddress Address) (manager *Manager, err error) {
-	return NewManager(sdk.backend, managerAddress)
+	return newManager(sdk.provider, managerAddress)
 }
 
 func (sdk *SDK) Version() struct {
@@ -0,0 +1,190 @@
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"io/ioutil"
+	"math/big"
+)
+
+/**
+Signer holds the key of an account and signs on behalf of it.
+The key does not need to be in process memory, e.g. it can be in an encrypted keystore file or a remote signer.
+*/
+type Signer interface {
+	// Address returns the address of the account
+	Address() Address
+	// SignTx signs the transaction for the chain with chainID
+	SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (signed *types.Transaction, err error)
+	// SignMessage signs the personal message (EIP-191), i.e. keccak256("\x19Ethereum Signed Message:\n" + len(message) + message).
+	// The signature is in [R || S || V] format where V is 27 or 28.
+	SignMessage(ctx context.Context, message []byte) (signature []byte, err error)
+	// SignTypedData signs the EIP-712 typed data, i.e. its Hash.
+	// The signature is in [R || S || V] format where V is 27 or 28.
+	SignTypedData(ctx context.Context, typedData *TypedData) (signature []byte, err error)
+}
+
+/**
+KeySigner signs with a private key in memory.
+*/
+type KeySigner struct {
+	address    common.Address
+	privateKey *ecdsa.PrivateKey
+}
+
+func NewKeySigner(privateKey *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		privateKey: privateKey,
+	}
+}
+
+func (s *KeySigner) Address() Address {
+	return Address(s.address.Hex())
+}
+
+func (s *KeySigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (signed *types.Transaction, err error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+}
+
+func (s *KeySigner) SignMessage(ctx context.Context, message []byte) (signature []byte, err error) {
+	return signPersonalMessage(s.privateKey, message)
+}
+
+func (s *KeySigner) SignTypedData(ctx context.Context, typedData *TypedData) (signature []byte, err error) {
+	return signTypedData(s.privateKey, typedData)
+}
+
+func signPersonalMessage(privateKey *ecdsa.PrivateKey, message []byte) (signature []byte, err error) {
+	return signHash(privateKey, accounts.TextHash(message))
+}
+
+func signTypedData(privateKey *ecdsa.PrivateKey, typedData *TypedData) (signature []byte, err error) {
+	hash, err := typedData.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return signHash(privateKey, hash.Bytes())
+}
+
+func signHash(privateKey *ecdsa.PrivateKey, hash []byte) (signature []byte, err error) {
+	signature, err = crypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, err
+	}
+	// weird Ethereum quirk
+	signature[64] += 27
+	return signature, nil
+}
+
+// recoverPersonalSigner recovers the address which signed the personal message, with V of the signature being 27/28 or 0/1
+func recoverPersonalSigner(message []byte, signature []byte) (signer common.Address, err error) {
+	return recoverHashSigner(accounts.TextHash(message), signature)
+}
+
+// recoverTypedDataSigner recovers the address which signed the EIP-712 typed data, with V of the signature being 27/28 or 0/1
+func recoverTypedDataSigner(typedData *TypedData, signature []byte) (signer common.Address, err error) {
+	hash, err := typedData.Hash()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return recoverHashSigner(hash.Bytes(), signature)
+}
+
+func recoverHashSigner(hash []byte, signature []byte) (signer common.Address, err error) {
+	if len(signature) != 65 {
+		return common.Address{}, InvalidSignatureErr
+	}
+	signature = common.CopyBytes(signature)
+	if signature[64] >= 27 {
+		signature[64] -= 27
+	}
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return common.Address{}, InvalidSignatureErr
+	}
+	return crypto.PubkeyToAddress(*publicKey), nil
+}
+
+/**
+KeystoreSigner signs with the key in a go-ethereum encrypted keystore file.
+The key is decrypted for each signature and wiped afterwards, so that it never stays in process memory.
+Note that the decryption is intentionally slow (scrypt).
+*/
+type KeystoreSigner struct {
+	address    common.Address
+	keyJSON    []byte
+	passphrase string
+}
+
+/**
+Creates a KeystoreSigner of the encrypted key JSON.
+The passphrase is checked by decrypting the key once.
+*/
+func NewKeystoreSigner(keyJSON []byte, passphrase string) (signer *KeystoreSigner, err error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key.PrivateKey)
+	return &KeystoreSigner{
+		address:    key.Address,
+		keyJSON:    keyJSON,
+		passphrase: passphrase,
+	}, nil
+}
+
+/**
+Creates a KeystoreSigner of the keystore file, e.g. one in the keystore directory of geth.
+*/
+func LoadKeystoreSigner(keyFile string, passphrase string) (signer *KeystoreSigner, err error) {
+	keyJSON, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	return NewKeystoreSigner(keyJSON, passphrase)
+}
+
+func (s *KeystoreSigner) Address() Address {
+	return Address(s.address.Hex())
+}
+
+func (s *KeystoreSigner) SignTx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (signed *types.Transaction, err error) {
+	key, err := keystore.DecryptKey(s.keyJSON, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key.PrivateKey)
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), key.PrivateKey)
+}
+
+func (s *KeystoreSigner) SignMessage(ctx context.Context, message []byte) (signature []byte, err error) {
+	key, err := keystore.DecryptKey(s.keyJSON, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key.PrivateKey)
+	return signPersonalMessage(key.PrivateKey, message)
+}
+
+func (s *KeystoreSigner) SignTypedData(ctx context.Context, typedData *TypedData) (signature []byte, err error) {
+	key, err := keystore.DecryptKey(s.keyJSON, s.passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(key.PrivateKey)
+	return signTypedData(key.PrivateKey, typedData)
+}
+
+// wipeKey overwrites the private key in memory
+func wipeKey(privateKey *ecdsa.PrivateKey) {
+	b := privateKey.D.Bits()
+	for i := range b {
+		b[i] = 0
+	}
+}
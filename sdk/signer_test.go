@@ -0,0 +1,147 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// signWith deploys a TFC and mints with the account, and checks the claim signature against the in-memory key
+func signWith(t *testing.T, account *Account, keyAccount *Account) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+
+	managerAddress, err := sdk.DeployManagerSync(context.Background(), account)
+	checkError(t, err)
+	manager, err := sdk.Manager(managerAddress)
+	checkError(t, err)
+	signature, err := manager.SignTFCClaim(PredefinedAccounts[1].Address(), big.NewInt(1), big.NewInt(0), account)
+	checkError(t, err)
+	expected, err := manager.SignTFCClaim(PredefinedAccounts[1].Address(), big.NewInt(1), big.NewInt(0), keyAccount)
+	checkError(t, err)
+	if signature != expected {
+		t.Fatal("claim signature is different from the one of the in-memory key")
+	}
+	checkError(t, manager.ClaimTFCSync(context.Background(), big.NewInt(1), big.NewInt(0), signature, PredefinedAccounts[1]))
+	signature, err = manager.SignTFCClaimTyped(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(1), big.NewInt(1), account)
+	checkError(t, err)
+	expected, err = manager.SignTFCClaimTyped(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(1), big.NewInt(1), keyAccount)
+	checkError(t, err)
+	if signature != expected {
+		t.Fatal("typed claim signature is different from the one of the in-memory key")
+	}
+
+	// both legacy and dynamic fee transactions are signed
+	for _, legacy := range []bool{false, true} {
+		mockEth.Backend.SetLegacy(legacy)
+		tfcAddress, err := sdk.DeployTFCSync(context.Background(), account)
+		checkError(t, err)
+		tfc, err := sdk.TFC(tfcAddress)
+		checkError(t, err)
+		checkError(t, tfc.MintSync(context.Background(), PredefinedAccounts[1].Address(), big.NewInt(100), account))
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	key, err := crypto.HexToECDSA(PredefinedPrivateKeys[0][2:])
+	checkError(t, err)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Id:         uuid.New(),
+		Address:    PredefinedAccounts[0].address,
+		PrivateKey: key,
+	}, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	checkError(t, err)
+	dir, err := ioutil.TempDir("", "keystore")
+	checkError(t, err)
+	defer os.RemoveAll(dir)
+	keyFile := filepath.Join(dir, "key.json")
+	checkError(t, ioutil.WriteFile(keyFile, keyJSON, 0600))
+
+	_, err = LoadKeystoreSigner(keyFile, "wrong")
+	if err != keystore.ErrDecrypt {
+		t.Fatal("expect ErrDecrypt, got", err)
+	}
+	signer, err := LoadKeystoreSigner(keyFile, "passphrase")
+	checkError(t, err)
+	if signer.Address() != PredefinedAccounts[0].Address() {
+		t.Fatal("keystore signer has wrong address")
+	}
+	signWith(t, NewAccount(signer), PredefinedAccounts[0])
+}
+
+func TestRemoteSigner(t *testing.T) {
+	mock := NewMockRemoteSigner(PredefinedAccounts[0].Signer())
+	defer mock.Stop()
+
+	signer := mock.Signer(PredefinedAccounts[0].Address())
+	signWith(t, NewAccount(signer), PredefinedAccounts[0])
+
+	message := []byte("message")
+	signature, err := signer.SignMessage(context.Background(), message)
+	checkError(t, err)
+	expected, err := PredefinedAccounts[0].Signer().SignMessage(context.Background(), message)
+	checkError(t, err)
+	if !bytes.Equal(signature, expected) {
+		t.Fatal("message signature is different from the one of the in-memory key")
+	}
+
+	// the remote signer does not hold the key of the account
+	_, err = mock.Signer(PredefinedAccounts[1].Address()).SignMessage(context.Background(), message)
+	if err == nil {
+		t.Fatal("remote signer should not sign for unknown account")
+	}
+}
+
+// lowVSignerService is a remote signer which returns signatures with V being 0/1
+type lowVSignerService struct {
+	*mockSignerService
+}
+
+func (s *lowVSignerService) Sign(ctx context.Context, address common.Address, data hexutil.Bytes) (hexutil.Bytes, error) {
+	signature, err := s.mockSignerService.Sign(ctx, address, data)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] -= 27
+	return signature, nil
+}
+
+func (s *lowVSignerService) SignTypedData_v4(ctx context.Context, address common.Address, typedData TypedData) (hexutil.Bytes, error) {
+	signature, err := s.mockSignerService.SignTypedData_v4(ctx, address, typedData)
+	if err != nil {
+		return nil, err
+	}
+	signature[64] -= 27
+	return signature, nil
+}
+
+func TestRemoteSigner_signature(t *testing.T) {
+	mock := NewMockRemoteSigner(PredefinedAccounts[0].Signer())
+	defer mock.Stop()
+
+	// V of 0/1 is normalized to 27/28
+	server := rpc.NewServer()
+	defer server.Stop()
+	checkError(t, server.RegisterName("eth", &lowVSignerService{&mockSignerService{mock}}))
+	signer := NewRemoteSigner(rpc.DialInProc(server), PredefinedAccounts[0].Address())
+	signWith(t, NewAccount(signer), PredefinedAccounts[0])
+
+	// the remote signer signs with the key of another account
+	mock.signers[PredefinedAccounts[1].address] = PredefinedAccounts[0].Signer()
+	_, err := mock.Signer(PredefinedAccounts[1].Address()).SignMessage(context.Background(), []byte("message"))
+	if err != SignerMismatchErr {
+		t.Fatal("expect SignerMismatchErr, got", err)
+	}
+}
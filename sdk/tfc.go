@@ -9,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
 	"math/big"
 	"strings"
 )
@@ -24,11 +25,15 @@ type TFC struct {
 Create a new TFC instance by providing the sdk object and the Address of TFC ERC20 contract
 */
 func NewTFC(backend Backend, tfcAddress Address) (tfc *TFC, err error) {
+	return newTFC(NewProvider(backend), tfcAddress)
+}
+
+func newTFC(p *provider, tfcAddress Address) (tfc *TFC, err error) {
 	tfc = &TFC{
-		provider: NewProvider(backend),
+		provider: p,
 		address:  tfcAddress,
 	}
-	tfc.contract, err = token.NewTFCToken(common.HexToAddress(string(tfcAddress)), backend)
+	tfc.contract, err = token.NewTFCToken(common.HexToAddress(string(tfcAddress)), p.backend)
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +93,114 @@ func (tfc *TFC) Allowance(owner Address, spender Address) (amount *big.Int, err
 	return tfc.contract.Allowance(nil, common.HexToAddress(string(owner)), common.HexToAddress(string(spender)))
 }
 
+/**
+Returns true if the token is paused, in which case all token transfers, mints and burns are rejected.
+*/
+func (tfc *TFC) IsPaused() (paused bool, err error) {
+	return tfc.contract.Paused(nil)
+}
+
+/**
+Returns TokenPausedErr if the token is paused.
+It is used to fail early instead of sending a transaction which will surely be reverted.
+*/
+func (tfc *TFC) checkNotPaused() (err error) {
+	paused, err := tfc.IsPaused()
+	if err != nil {
+		return err
+	}
+	if paused {
+		return TokenPausedErr
+	}
+	return nil
+}
+
+/**
+Returns the role identifier (bytes32) of the given role in the smart contract.
+*/
+func (tfc *TFC) roleID(role Role) (id [32]byte, err error) {
+	switch role {
+	case AdminRole:
+		return tfc.contract.DEFAULTADMINROLE(nil)
+	case MinterRole:
+		return tfc.contract.MINTERROLE(nil)
+	case PauserRole:
+		return tfc.contract.PAUSERROLE(nil)
+	case BurnerRole:
+		return tfc.contract.BURNERROLE(nil)
+	default:
+		return id, InvalidRoleError
+	}
+}
+
+/**
+Returns true if the account has been granted the role.
+*/
+func (tfc *TFC) HasRole(role Role, account Address) (has bool, err error) {
+	if !account.IsValid() {
+		return false, InvalidAddressError
+	}
+	id, err := tfc.roleID(role)
+	if err != nil {
+		return false, err
+	}
+	return tfc.contract.HasRole(nil, id, account.address())
+}
+
+/**
+Returns all accounts that have the role.
+*/
+func (tfc *TFC) RoleMembers(role Role) (members []Address, err error) {
+	id, err := tfc.roleID(role)
+	if err != nil {
+		return nil, err
+	}
+	count, err := tfc.contract.GetRoleMemberCount(nil, id)
+	if err != nil {
+		return nil, err
+	}
+	members = make([]Address, 0, count.Int64())
+	for i := big.NewInt(0); i.Cmp(count) < 0; i.Add(i, big.NewInt(1)) {
+		member, err := tfc.contract.GetRoleMember(nil, id, i)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, Address(member.Hex()))
+	}
+	return members, nil
+}
+
+/**
+Returns the admin role that controls the given role, i.e. accounts with the admin role can grant and revoke the given role.
+*/
+func (tfc *TFC) RoleAdmin(role Role) (admin Role, err error) {
+	id, err := tfc.roleID(role)
+	if err != nil {
+		return 0, err
+	}
+	adminID, err := tfc.contract.GetRoleAdmin(nil, id)
+	if err != nil {
+		return 0, err
+	}
+	return tfc.roleOf(adminID)
+}
+
+/**
+Returns the role of the given role identifier (bytes32) in the smart contract.
+*/
+func (tfc *TFC) roleOf(id [32]byte) (role Role, err error) {
+	for _, r := range Roles {
+		rID, err := tfc.roleID(r)
+		if err != nil {
+			return 0, err
+		}
+		if rID == id {
+			return r, nil
+		}
+	}
+	return 0, InvalidRoleError
+}
+
 /* Send wrappers */
 
 /**
@@ -95,25 +208,21 @@ Transfer the amount of balance from current Account (specified in SDK) to the gi
 
 This function requires privateKey has been set in SDK.
 */
-func (tfc *TFC) Transfer(ctx context.Context, to Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
-	doneCh = make(chan interface{}, 0)
-	errCh = make(chan error, 0)
-	auth := bind.NewKeyedTransactor(sender.privateKey)
-	tx, err := tfc.contract.Transfer(auth, to.address(), amount)
-	if err != nil {
-		errCh <- err
-		return doneCh, errCh
+func (tfc *TFC) TransferTx(ctx context.Context, to Address, amount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
 	}
-	receiptCh, eCh := tfc.AsyncTransaction(ctx, tx.Hash(), ConfirmationRequirement)
-	go func() {
-		select {
-		case <-receiptCh:
-			close(doneCh)
-		case err := <-eCh:
-			errCh <- err
-		}
-	}()
-	return doneCh, errCh
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.Transfer(auth, to.address(), amount)
+	})
+}
+
+/**
+Transfer is the same as TransferTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) Transfer(ctx context.Context, to Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.TransferTx(ctx, to, amount, sender)
+	return asyncDone(ctx, pending, err)
 }
 
 func (tfc *TFC) TransferSync(ctx context.Context, to Address, amount *big.Int, sender *Account) (err error) {
@@ -128,20 +237,136 @@ func (tfc *TFC) TransferSync(ctx context.Context, to Address, amount *big.Int, s
 
 /**
 Transfer the amount of balance from the given "from" Account to the given "to" Account.
+The sender must have been approved by "from" Account to spend at least the amount of tokens (see Approve).
 
 This function requires privateKey has been set in SDK, which will be used to sign the ethereum transaction.
 */
-func (tfc *TFC) TransferFrom(ctx context.Context, from Address, to string, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
-	panic(UnimplementedError)
+func (tfc *TFC) TransferFromTx(ctx context.Context, from Address, to Address, amount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if !from.IsValid() || !to.IsValid() {
+		return nil, InvalidAddressError
+	}
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
+	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.TransferFrom(auth, from.address(), to.address(), amount)
+	})
+}
+
+/**
+TransferFrom is the same as TransferFromTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) TransferFrom(ctx context.Context, from Address, to Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.TransferFromTx(ctx, from, to, amount, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) TransferFromSync(ctx context.Context, from Address, to Address, amount *big.Int, sender *Account) (err error) {
+	doneCh, errCh := tfc.TransferFrom(ctx, from, to, amount, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
 }
 
 /**
 Allows spender to withdraw from the current Account (specified in SDK) multiple times, up to the given amount.
+The previous allowance is overwritten.
 
 This function requires privateKey has been set in SDK.
 */
+func (tfc *TFC) ApproveTx(ctx context.Context, spender Address, amount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if !spender.IsValid() {
+		return nil, InvalidAddressError
+	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.Approve(auth, spender.address(), amount)
+	})
+}
+
+/**
+Approve is the same as ApproveTx, except that the confirmation of the transaction is notified via channels.
+*/
 func (tfc *TFC) Approve(ctx context.Context, spender Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
-	panic(UnimplementedError)
+	pending, err := tfc.ApproveTx(ctx, spender, amount, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) ApproveSync(ctx context.Context, spender Address, amount *big.Int, sender *Account) (err error) {
+	doneCh, errCh := tfc.Approve(ctx, spender, amount, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+/**
+Atomically increases the allowance granted to spender by the current Account (specified in SDK).
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) IncreaseAllowanceTx(ctx context.Context, spender Address, addedAmount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if !spender.IsValid() {
+		return nil, InvalidAddressError
+	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.IncreaseAllowance(auth, spender.address(), addedAmount)
+	})
+}
+
+/**
+IncreaseAllowance is the same as IncreaseAllowanceTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) IncreaseAllowance(ctx context.Context, spender Address, addedAmount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.IncreaseAllowanceTx(ctx, spender, addedAmount, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) IncreaseAllowanceSync(ctx context.Context, spender Address, addedAmount *big.Int, sender *Account) (err error) {
+	doneCh, errCh := tfc.IncreaseAllowance(ctx, spender, addedAmount, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+/**
+Atomically decreases the allowance granted to spender by the current Account (specified in SDK).
+The allowance cannot go below zero.
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) DecreaseAllowanceTx(ctx context.Context, spender Address, subtractedAmount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if !spender.IsValid() {
+		return nil, InvalidAddressError
+	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.DecreaseAllowance(auth, spender.address(), subtractedAmount)
+	})
+}
+
+/**
+DecreaseAllowance is the same as DecreaseAllowanceTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) DecreaseAllowance(ctx context.Context, spender Address, subtractedAmount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.DecreaseAllowanceTx(ctx, spender, subtractedAmount, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) DecreaseAllowanceSync(ctx context.Context, spender Address, subtractedAmount *big.Int, sender *Account) (err error) {
+	doneCh, errCh := tfc.DecreaseAllowance(ctx, spender, subtractedAmount, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
 }
 
 /**
@@ -150,25 +375,21 @@ This function can only be called by Account (specified in SDK) which has MINTER_
 
 This function requires privateKey has been set in SDK.
 */
-func (tfc *TFC) Mint(ctx context.Context, to Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
-	doneCh = make(chan interface{}, 0)
-	errCh = make(chan error, 0)
-	auth := bind.NewKeyedTransactor(sender.privateKey)
-	tx, err := tfc.contract.Mint(auth, to.address(), amount)
-	if err != nil {
-		errCh <- err
-		return doneCh, errCh
+func (tfc *TFC) MintTx(ctx context.Context, to Address, amount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
 	}
-	receiptCh, eCh := tfc.AsyncTransaction(ctx, tx.Hash(), ConfirmationRequirement)
-	go func() {
-		select {
-		case <-receiptCh:
-			close(doneCh)
-		case err := <-eCh:
-			errCh <- err
-		}
-	}()
-	return doneCh, errCh
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.Mint(auth, to.address(), amount)
+	})
+}
+
+/**
+Mint is the same as MintTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) Mint(ctx context.Context, to Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.MintTx(ctx, to, amount, sender)
+	return asyncDone(ctx, pending, err)
 }
 
 func (tfc *TFC) MintSync(ctx context.Context, to Address, amount *big.Int, sender *Account) (err error) {
@@ -181,62 +402,295 @@ func (tfc *TFC) MintSync(ctx context.Context, to Address, amount *big.Int, sende
 	}
 }
 
-/* Anonymous wrappers */
+/**
+Destroys the amount of tokens from the balance of the sender.
+This function can only be called by Account which has BURNER_ROLE of smart contract.
 
-func (tfc *TFC) BridgeTFCExchange(ctx context.Context, depositTransactionHash string, amount *big.Int, minter *Account, depositTransactionConfirmationRequirement int) (recipient Address, transactionHashErr error, doneCh chan interface{}, errCh chan error) {
-	chainID, err := tfc.backend.NetworkID(context.Background())
-	if err != nil {
-		return "", err, nil, nil
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) BurnTx(ctx context.Context, amount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
 	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.Burn(auth, amount)
+	})
+}
 
-	tx, pending, err := tfc.backend.TransactionByHash(ctx, common.HexToHash(depositTransactionHash))
-	if err == ethereum.NotFound {
-		return "", UnknownTransactionHashErr, nil, nil
-	} else if err != nil {
-		return "", err, nil, nil
+/**
+Burn is the same as BurnTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) Burn(ctx context.Context, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.BurnTx(ctx, amount, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) BurnSync(ctx context.Context, amount *big.Int, sender *Account) (err error) {
+	doneCh, errCh := tfc.Burn(ctx, amount, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
 	}
-	if pending {
-		return "", UnconfirmedTransactionErr, nil, nil
+}
+
+/**
+Destroys the amount of tokens from the balance of the given account, deducting from the sender's allowance (see Approve).
+This function can only be called by Account which has BURNER_ROLE of smart contract.
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) BurnFromTx(ctx context.Context, account Address, amount *big.Int, sender *Account) (pending *PendingTx, err error) {
+	if !account.IsValid() {
+		return nil, InvalidAddressError
+	}
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
+	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.BurnFrom(auth, account.address(), amount)
+	})
+}
+
+/**
+BurnFrom is the same as BurnFromTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) BurnFrom(ctx context.Context, account Address, amount *big.Int, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.BurnFromTx(ctx, account, amount, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) BurnFromSync(ctx context.Context, account Address, amount *big.Int, sender *Account) (err error) {
+	doneCh, errCh := tfc.BurnFrom(ctx, account, amount, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
 	}
+}
 
-	msg, err := tx.AsMessage(types.NewEIP155Signer(chainID))
+/**
+Pauses all token transfers, mints and burns.
+This function can only be called by Account which has PAUSER_ROLE of smart contract.
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) PauseTx(ctx context.Context, sender *Account) (pending *PendingTx, err error) {
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.Pause(auth)
+	})
+}
+
+/**
+Pause is the same as PauseTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) Pause(ctx context.Context, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.PauseTx(ctx, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) PauseSync(ctx context.Context, sender *Account) (err error) {
+	doneCh, errCh := tfc.Pause(ctx, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+/**
+Unpauses all token transfers, mints and burns.
+This function can only be called by Account which has PAUSER_ROLE of smart contract.
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) UnpauseTx(ctx context.Context, sender *Account) (pending *PendingTx, err error) {
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.Unpause(auth)
+	})
+}
+
+/**
+Unpause is the same as UnpauseTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) Unpause(ctx context.Context, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.UnpauseTx(ctx, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) UnpauseSync(ctx context.Context, sender *Account) (err error) {
+	doneCh, errCh := tfc.Unpause(ctx, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+/**
+Grants the role to the account.
+This function can only be called by Account which has the admin role of the given role (see RoleAdmin).
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) GrantRoleTx(ctx context.Context, role Role, account Address, sender *Account) (pending *PendingTx, err error) {
+	if !account.IsValid() {
+		return nil, InvalidAddressError
+	}
+	id, err := tfc.roleID(role)
 	if err != nil {
-		return "", err, nil, nil
+		return nil, err
 	}
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.GrantRole(auth, id, account.address())
+	})
+}
 
-	receipt, err := tfc.backend.TransactionReceipt(ctx, common.HexToHash(depositTransactionHash))
-	if err == ethereum.NotFound {
-		return "", UnknownTransactionHashErr, nil, nil
-	} else if err != nil {
-		return "", err, nil, nil
+/**
+GrantRole is the same as GrantRoleTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) GrantRole(ctx context.Context, role Role, account Address, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.GrantRoleTx(ctx, role, account, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) GrantRoleSync(ctx context.Context, role Role, account Address, sender *Account) (err error) {
+	doneCh, errCh := tfc.GrantRole(ctx, role, account, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
 	}
-	// check if receipt is on canonical chain
-	blockHash := receipt.BlockHash
-	canonicalBlock, err := tfc.backend.BlockByNumber(ctx, receipt.BlockNumber)
+}
+
+/**
+Revokes the role from the account.
+This function can only be called by Account which has the admin role of the given role (see RoleAdmin).
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) RevokeRoleTx(ctx context.Context, role Role, account Address, sender *Account) (pending *PendingTx, err error) {
+	if !account.IsValid() {
+		return nil, InvalidAddressError
+	}
+	id, err := tfc.roleID(role)
 	if err != nil {
-		return "", err, nil, nil
+		return nil, err
 	}
-	if blockHash != canonicalBlock.Hash() {
-		return "", UnconfirmedTransactionErr, nil, nil
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.RevokeRole(auth, id, account.address())
+	})
+}
+
+/**
+RevokeRole is the same as RevokeRoleTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) RevokeRole(ctx context.Context, role Role, account Address, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.RevokeRoleTx(ctx, role, account, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) RevokeRoleSync(ctx context.Context, role Role, account Address, sender *Account) (err error) {
+	doneCh, errCh := tfc.RevokeRole(ctx, role, account, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
 	}
-	currentBlock, err := tfc.backend.BlockByNumber(ctx, nil)
+}
+
+/**
+Revokes the role from the calling account, e.g. when a minter key is compromised.
+The given account must be the sender itself.
+
+This function requires privateKey has been set in SDK.
+*/
+func (tfc *TFC) RenounceRoleTx(ctx context.Context, role Role, account Address, sender *Account) (pending *PendingTx, err error) {
+	if !account.IsValid() {
+		return nil, InvalidAddressError
+	}
+	id, err := tfc.roleID(role)
 	if err != nil {
-		return "", err, nil, nil
+		return nil, err
 	}
-	if currentBlock.Number().Sub(currentBlock.Number(), receipt.BlockNumber).Cmp(big.NewInt(int64(depositTransactionConfirmationRequirement))) < 0 {
-		return "", UnconfirmedTransactionErr, nil, nil
+	return tfc.transact(ctx, sender, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		return tfc.contract.RenounceRole(auth, id, account.address())
+	})
+}
+
+/**
+RenounceRole is the same as RenounceRoleTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (tfc *TFC) RenounceRole(ctx context.Context, role Role, account Address, sender *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := tfc.RenounceRoleTx(ctx, role, account, sender)
+	return asyncDone(ctx, pending, err)
+}
+
+func (tfc *TFC) RenounceRoleSync(ctx context.Context, role Role, account Address, sender *Account) (err error) {
+	doneCh, errCh := tfc.RenounceRole(ctx, role, account, sender)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
 	}
-	recipient = Address(msg.From().Hex())
-	// transaction confirmed
-	doneCh, errCh = tfc.Mint(ctx, recipient, amount, minter)
+}
+
+/* Anonymous wrappers */
+
+/**
+BridgeTFCExchange is the same as BridgeTFCExchangeTx, except that the confirmation of the mint transaction is notified via channels.
+transactionHashErr is the error of the deposit transaction (including DepositUsedErr), which is returned without channels,
+while the errors of sending and confirming the mint transaction are fed to errCh.
+*/
+func (tfc *TFC) BridgeTFCExchange(ctx context.Context, depositTransactionHash string, amount *big.Int, minter *Account, depositTransactionConfirmationRequirement int) (recipient Address, transactionHashErr error, doneCh chan interface{}, errCh chan error) {
+	recipient, pending, err := tfc.BridgeTFCExchangeTx(ctx, depositTransactionHash, amount, minter, depositTransactionConfirmationRequirement)
+	if recipient == "" || err == DepositUsedErr {
+		return "", err, nil, nil
+	}
+	doneCh, errCh = asyncDone(ctx, pending, err)
 	return recipient, nil, doneCh, errCh
 }
 
-func (tfc *TFC) EstimateTFCExchangeFee(ctx context.Context, recipient Address, amount *big.Int, bridgeAccount *Account, minGas uint64, transactionFeeRate float64) (requiredTransferAmount *big.Int, estimatedGas uint64, gasPrice *big.Int, err error) {
-	gasPrice, err = tfc.backend.SuggestGasPrice(ctx)
+/**
+Estimate the transaction fee of the mint transaction of a bridge exchange, including the fee rate,
+and return it as a Quote signed by bridgeAccount, which expires after QuoteValidity or QuoteValidityBlocks.
+The fee is quoted against the worst-case price per gas, i.e. the max fee per gas if dynamic fee transactions are sent (see FeeStrategy).
+The quote should be given back to SendMintTx, which rejects it if it is expired or altered.
+*/
+func (tfc *TFC) EstimateTFCExchangeFee(ctx context.Context, recipient Address, amount *big.Int, bridgeAccount *Account, minGas uint64, transactionFeeRate float64) (quote *Quote, err error) {
+	requiredTransferAmount, estimatedGas, gasPrice, err := tfc.estimateTFCExchangeFee(ctx, recipient, amount, bridgeAccount, minGas, transactionFeeRate)
+	if err != nil {
+		return nil, err
+	}
+	quote = &Quote{
+		ID:                     uuid.New().String(),
+		Recipient:              recipient,
+		Amount:                 amount,
+		TransactionFeeRate:     transactionFeeRate,
+		EstimatedGas:           estimatedGas,
+		GasPrice:               gasPrice,
+		RequiredTransferAmount: requiredTransferAmount,
+	}
+	if err := tfc.signQuote(ctx, quote, bridgeAccount); err != nil {
+		return nil, err
+	}
+	return quote, nil
+}
+
+// estimateTFCExchangeFee returns the fee of the mint transaction of a bridge exchange without quoting it
+func (tfc *TFC) estimateTFCExchangeFee(ctx context.Context, recipient Address, amount *big.Int, bridgeAccount *Account, minGas uint64, transactionFeeRate float64) (requiredTransferAmount *big.Int, estimatedGas uint64, gasPrice *big.Int, err error) {
+	fees, err := tfc.suggestFees(ctx)
 	if err != nil {
 		return nil, 0, nil, err
 	}
+	gasPrice = fees.MaxGasPrice()
 	parsedABI, err := abi.JSON(strings.NewReader(token.TFCTokenABI))
 	if err != nil {
 		return nil, 0, nil, err
@@ -255,7 +709,8 @@ func (tfc *TFC) EstimateTFCExchangeFee(ctx context.Context, recipient Address, a
 	}
 	// If the contract surely has code (or code is not needed), estimate the transaction
 	tfcAddress := tfc.address.address()
-	msg := ethereum.CallMsg{From: bridgeAccount.address, To: &tfcAddress, GasPrice: gasPrice, Value: big.NewInt(0), Data: input}
+	msg := ethereum.CallMsg{From: bridgeAccount.address, To: &tfcAddress, Value: big.NewInt(0), Data: input}
+	fees.applyCall(&msg)
 	estimatedGas, err = tfc.backend.EstimateGas(ctx, msg)
 	if err != nil && (strings.Contains(err.Error(), "insufficient funds") || strings.Contains(err.Error(), "gas required exceeds allowance")) {
 		estimatedGas = 60000 // if estimate gas fails due to bridge account has no balance, assign a default safe gasLimit for ERC20 mint
@@ -277,67 +732,139 @@ func (tfc *TFC) EstimateTFCExchangeFee(ctx context.Context, recipient Address, a
 	return requiredTransferAmount, estimatedGas, gasPrice, nil
 }
 
+/**
+Check the deposit transaction of the bridge fee, and return the sender (i.e. the recipient of the exchange) and the deposit amount in wei.
+See CheckFeeDeposit.
+*/
 func (tfc *TFC) CheckTransactionFeeDeposit(ctx context.Context, depositTransactionHash string, bridgeAccountAddress Address, depositTransactionConfirmationRequirement int) (recipient Address, depositAmount *big.Int, err error) {
-	chainID, err := tfc.backend.NetworkID(context.Background())
-	if err != nil {
+	deposit, err := tfc.CheckFeeDeposit(ctx, depositTransactionHash, bridgeAccountAddress, depositTransactionConfirmationRequirement)
+	if deposit == nil {
 		return "", nil, err
 	}
+	if err != nil {
+		return "", deposit.Amount, err
+	}
+	return deposit.Sender, deposit.Amount, nil
+}
+
+/**
+Check the deposit transaction of the bridge fee, which either transfers ETH to the bridge account,
+or emits Transfer events of an ERC-20 token accepted by AcceptFeeToken to the bridge account.
+A token deposit must be paid by one sender in one token, and its amount is converted to wei by the PriceOracle of the token.
+InvalidDepositErr is returned if the transaction is not a deposit to the bridge account,
+and UnconfirmedTransactionErr (with the deposit if known) if it is not confirmed by depositTransactionConfirmationRequirement blocks.
+*/
+func (tfc *TFC) CheckFeeDeposit(ctx context.Context, depositTransactionHash string, bridgeAccountAddress Address, depositTransactionConfirmationRequirement int) (deposit *FeeDeposit, err error) {
+	signer, err := tfc.signer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bridge := bridgeAccountAddress.address()
 
 	tx, pending, err := tfc.backend.TransactionByHash(ctx, common.HexToHash(depositTransactionHash))
 	if err == ethereum.NotFound {
-		return "", nil, UnknownTransactionHashErr
+		return nil, UnknownTransactionHashErr
 	} else if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	if pending {
-		return "", tx.Value(), UnconfirmedTransactionErr
+		if tx.To() != nil && *tx.To() == bridge {
+			return &FeeDeposit{Amount: tx.Value()}, UnconfirmedTransactionErr
+		}
+		return nil, UnconfirmedTransactionErr
 	}
 
-	msg, err := tx.AsMessage(types.NewEIP155Signer(chainID))
+	from, err := types.Sender(signer, tx)
 	if err != nil {
-		return "", nil, err
-	}
-
-	if *msg.To() != bridgeAccountAddress.address() {
-		return "", nil, InvalidDepositErr
+		return nil, err
 	}
 
 	receipt, err := tfc.backend.TransactionReceipt(ctx, common.HexToHash(depositTransactionHash))
 	if err == ethereum.NotFound {
-		return "", nil, UnknownTransactionHashErr
+		return nil, UnknownTransactionHashErr
 	} else if err != nil {
-		return "", nil, err
+		return nil, err
 	}
+	if tx.To() != nil && *tx.To() == bridge {
+		deposit = &FeeDeposit{Sender: Address(from.Hex()), Amount: tx.Value()}
+	} else {
+		deposit, err = tfc.tokenFeeDeposit(ctx, receipt, bridge)
+		if err != nil {
+			return nil, err
+		}
+		if deposit == nil {
+			return nil, InvalidDepositErr
+		}
+	}
+
 	// check if receipt is on canonical chain
 	blockHash := receipt.BlockHash
 	canonicalBlock, err := tfc.backend.BlockByNumber(ctx, receipt.BlockNumber)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	if blockHash != canonicalBlock.Hash() {
-		return "", tx.Value(), UnconfirmedTransactionErr
+		return deposit, UnconfirmedTransactionErr
 	}
 	currentBlock, err := tfc.backend.BlockByNumber(ctx, nil)
 	if err != nil {
-		return "", nil, err
+		return nil, err
 	}
 	if currentBlock.Number().Sub(currentBlock.Number(), receipt.BlockNumber).Cmp(big.NewInt(int64(depositTransactionConfirmationRequirement))) < 0 {
-		return "", tx.Value(), UnconfirmedTransactionErr
+		return deposit, UnconfirmedTransactionErr
 	}
-	recipient = Address(msg.From().Hex())
-	return recipient, tx.Value(), nil
+	return deposit, nil
 }
 
-func (tfc *TFC) SendMintTransaction(ctx context.Context, recipient Address, amount *big.Int, minter *Account, depositAmount *big.Int, estimatedGas uint64, gasPrice *big.Int, transactionFeeRate float64) (mintTransactionHash string, err error) {
+/**
+Send the mint transaction of a bridge exchange quoted by EstimateTFCExchangeFee, using the deposit amount as transaction fee.
+The quote must be signed by minter and not expired, otherwise InvalidQuoteErr or QuoteExpiredErr is returned (see VerifyQuote),
+and DepositBelowQuoteErr is returned if depositAmount is less than the RequiredTransferAmount of the quote.
+The deposit transaction and the quote are claimed in the DepositLedger so that each of them is minted at most once,
+and DepositUsedErr is returned if the deposit has been used, or QuoteUsedErr if the quote has been used.
+If sending fails after the mint transaction may have been broadcast, both stay claimed with the mint transaction recorded.
+The returned mint transaction is not bumped automatically by Wait, since its hash is recorded in the DepositLedger.
+*/
+func (tfc *TFC) SendMintTx(ctx context.Context, quote *Quote, depositTransactionHash string, minter *Account, depositAmount *big.Int) (pending *PendingTx, err error) {
+	if err := tfc.VerifyQuote(ctx, quote, minter.Address()); err != nil {
+		return nil, err
+	}
+	if depositAmount == nil || depositAmount.Cmp(quote.RequiredTransferAmount) < 0 {
+		return nil, DepositBelowQuoteErr
+	}
+	if claimed, _, err := tfc.DepositLedger().Lookup(quote.ledgerKey()); err != nil {
+		return nil, err
+	} else if claimed {
+		return nil, QuoteUsedErr
+	}
+	pending, err = tfc.sendForDeposit(ctx, []Hash{quote.ledgerKey(), Hash(depositTransactionHash)}, func(broadcast func(tx *types.Transaction) error) (*PendingTx, error) {
+		return tfc.sendMintTx(ctx, quote.Recipient, quote.Amount, minter, depositAmount, quote.EstimatedGas, quote.GasPrice, quote.TransactionFeeRate, broadcast)
+	})
+	if err != nil {
+		return pending, err
+	}
+	// the recorded mint transaction must not be replaced
+	pending.SetBumpPolicy(nil)
+	return pending, nil
+}
+
+/**
+sendMintTx sends the mint transaction of a bridge exchange without a quote.
+gasPrice is the worst-case price per gas, which is the max fee per gas if a dynamic fee transaction is sent (see FeeStrategy).
+If gasPrice is nil or zero, it is calculated from the deposit amount and estimatedGas.
+broadcast (if not nil) is called with the signed mint transaction to send it instead,
+e.g. to persist it before it is sent, and the returned error is the error of sending.
+*/
+func (tfc *TFC) sendMintTx(ctx context.Context, recipient Address, amount *big.Int, minter *Account, depositAmount *big.Int, estimatedGas uint64, gasPrice *big.Int, transactionFeeRate float64, broadcast func(tx *types.Transaction) error) (pending *PendingTx, err error) {
 	// get the fee received from user
 	receivedFee := depositAmount
 	// make sure the minter account has at least receivedFee amount of ETH
 	balance, err := tfc.backend.BalanceAt(ctx, minter.address, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if balance.Cmp(receivedFee) < 0 {
-		return "", InsufficientBalanceErr
+		return nil, InsufficientBalanceErr
 	}
 	// deduct transaction fee rate
 	txFee := new(big.Float).Quo(new(big.Float).SetInt(receivedFee), big.NewFloat(1+transactionFeeRate))
@@ -345,34 +872,42 @@ func (tfc *TFC) SendMintTransaction(ctx context.Context, recipient Address, amou
 	// encode input
 	parsedABI, err := abi.JSON(strings.NewReader(token.TFCTokenABI))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	// pack mint transaction input
 	input, err := parsedABI.Pack("mint", recipient.address(), amount)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	// estimate gas if estimatedGas == 0
 	// Gas estimation cannot succeed without code for method invocations
 	if code, err := tfc.backend.PendingCodeAt(ctx, tfc.address.address()); err != nil {
-		return "", err
+		return nil, err
 	} else if len(code) == 0 {
-		return "", bind.ErrNoCode
+		return nil, bind.ErrNoCode
+	}
+	// the fee strategy decides whether a dynamic fee transaction is sent
+	fees, err := tfc.suggestFees(ctx)
+	if err != nil {
+		return nil, err
 	}
 	// If the contract surely has code (or code is not needed), estimate the transaction
 	tfcAddress := tfc.address.address()
-	msg := ethereum.CallMsg{From: minter.address, To: &tfcAddress, GasPrice: gasPrice, Value: big.NewInt(0), Data: input}
+	msg := ethereum.CallMsg{From: minter.address, To: &tfcAddress, Value: big.NewInt(0), Data: input}
+	if gasPrice != nil && gasPrice.Sign() > 0 {
+		fees.withMaxGasPrice(gasPrice).applyCall(&msg)
+	}
 	gas, err := tfc.backend.EstimateGas(ctx, msg)
 	if err != nil && strings.Contains(err.Error(), "insufficient funds") {
 		estimatedGas = 60000 // if estimate gas fails due to bridge account does not have enough balance, assign a default safe gasLimit for ERC20 mint
 	} else if err != nil {
-		return "", fmt.Errorf("failed to estimate gas needed: %v", err)
+		return nil, fmt.Errorf("failed to estimate gas needed: %v", err)
 	} else {
 		if estimatedGas == 0 {
 			estimatedGas = gas
 		} else if estimatedGas > 0 && estimatedGas < gas {
-			return "", InsufficientGasErr
+			return nil, InsufficientGasErr
 		}
 	}
 
@@ -384,77 +919,141 @@ func (tfc *TFC) SendMintTransaction(ctx context.Context, recipient Address, amou
 	}
 
 	if txFee.Cmp(new(big.Float).Mul(big.NewFloat(float64(estimatedGas)), new(big.Float).SetInt(gasPrice))) < 0 {
-		return "", InsufficientTransactionFeeErr
+		return nil, InsufficientTransactionFeeErr
 	}
 
 	// send mint transaction
-	auth := bind.NewKeyedTransactor(minter.privateKey)
-	auth.GasLimit = estimatedGas
-	auth.GasPrice = gasPrice
-	tx, err := tfc.contract.Mint(auth, recipient.address(), amount)
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
+	}
+	return tfc.transactWithFees(ctx, minter, fees.withMaxGasPrice(gasPrice), func(auth *bind.TransactOpts) (*types.Transaction, error) {
+		auth.GasLimit = estimatedGas
+		if broadcast == nil {
+			return tfc.contract.Mint(auth, recipient.address(), amount)
+		}
+		auth.NoSend = true
+		tx, err := tfc.contract.Mint(auth, recipient.address(), amount)
+		if err != nil {
+			return nil, err
+		}
+		if err := broadcast(tx); err != nil {
+			return nil, err
+		}
+		return tx, nil
+	})
+}
+
+/**
+SendMintTransaction is the same as SendMintTx, except that only the hash of the mint transaction is returned.
+*/
+func (tfc *TFC) SendMintTransaction(ctx context.Context, quote *Quote, depositTransactionHash string, minter *Account, depositAmount *big.Int) (mintTransactionHash string, err error) {
+	pending, err := tfc.SendMintTx(ctx, quote, depositTransactionHash, minter, depositAmount)
 	if err != nil {
 		return "", err
 	}
-	return tx.Hash().Hex(), nil
+	return string(pending.Hash()), nil
 }
 
-func (tfc *TFC) BridgeTFCExchangeAsync(ctx context.Context, depositTransactionHash string, amount *big.Int, minter *Account, depositTransactionConfirmationRequirement int) (recipient Address, mintTransactionHash string, err error) {
-	chainID, err := tfc.backend.NetworkID(context.Background())
+/**
+Check the deposit transaction and send the mint transaction of a bridge exchange to the sender of the deposit transaction.
+The recipient is returned once the deposit transaction is accepted, even if the mint transaction cannot be sent.
+DepositUsedErr is returned if the deposit transaction has been used by another bridge exchange (see DepositLedger).
+If sending the mint transaction fails after it may have been broadcast, the deposit stays claimed with the mint transaction recorded.
+*/
+func (tfc *TFC) BridgeTFCExchangeTx(ctx context.Context, depositTransactionHash string, amount *big.Int, minter *Account, depositTransactionConfirmationRequirement int) (recipient Address, pending *PendingTx, err error) {
+	signer, err := tfc.signer(ctx)
 	if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 
-	tx, pending, err := tfc.backend.TransactionByHash(ctx, common.HexToHash(depositTransactionHash))
+	tx, isPending, err := tfc.backend.TransactionByHash(ctx, common.HexToHash(depositTransactionHash))
 	if err == ethereum.NotFound {
-		return "", "", UnknownTransactionHashErr
+		return "", nil, UnknownTransactionHashErr
 	} else if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
-	if pending {
-		return "", "", UnconfirmedTransactionErr
+	if isPending {
+		return "", nil, UnconfirmedTransactionErr
 	}
 
-	msg, err := tx.AsMessage(types.NewEIP155Signer(chainID))
+	from, err := types.Sender(signer, tx)
 	if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 
 	receipt, err := tfc.backend.TransactionReceipt(ctx, common.HexToHash(depositTransactionHash))
 	if err == ethereum.NotFound {
-		return "", "", UnknownTransactionHashErr
+		return "", nil, UnknownTransactionHashErr
 	} else if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 	// check if receipt is on canonical chain
 	blockHash := receipt.BlockHash
 	canonicalBlock, err := tfc.backend.BlockByNumber(ctx, receipt.BlockNumber)
 	if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 	if blockHash != canonicalBlock.Hash() {
-		return "", "", UnconfirmedTransactionErr
+		return "", nil, UnconfirmedTransactionErr
 	}
 	currentBlock, err := tfc.backend.BlockByNumber(ctx, nil)
 	if err != nil {
-		return "", "", err
+		return "", nil, err
 	}
 	if currentBlock.Number().Sub(currentBlock.Number(), receipt.BlockNumber).Cmp(big.NewInt(int64(depositTransactionConfirmationRequirement))) < 0 {
-		return "", "", UnconfirmedTransactionErr
+		return "", nil, UnconfirmedTransactionErr
 	}
-	recipient = Address(msg.From().Hex())
+	recipient = Address(from.Hex())
 
 	// send mint transaction
-	auth := bind.NewKeyedTransactor(minter.privateKey)
-	tx, err = tfc.contract.Mint(auth, recipient.address(), amount)
+	pending, err = tfc.mintForDeposit(ctx, depositTransactionHash, recipient, amount, minter)
+	return recipient, pending, err
+}
+
+/**
+Send the mint transaction of the deposit transaction, which is claimed in the DepositLedger beforehand so that it is minted at most once
+(see sendForDeposit).
+The returned mint transaction is not bumped automatically by Wait, since its hash is recorded in the DepositLedger.
+*/
+func (tfc *TFC) mintForDeposit(ctx context.Context, depositTransactionHash string, recipient Address, amount *big.Int, minter *Account) (pending *PendingTx, err error) {
+	if err := tfc.checkNotPaused(); err != nil {
+		return nil, err
+	}
+	pending, err = tfc.sendForDeposit(ctx, []Hash{Hash(depositTransactionHash)}, func(broadcast func(tx *types.Transaction) error) (*PendingTx, error) {
+		return tfc.transact(ctx, minter, func(auth *bind.TransactOpts) (*types.Transaction, error) {
+			auth.NoSend = true
+			tx, err := tfc.contract.Mint(auth, recipient.address(), amount)
+			if err != nil {
+				return nil, err
+			}
+			if err := broadcast(tx); err != nil {
+				return nil, err
+			}
+			return tx, nil
+		})
+	})
 	if err != nil {
+		return pending, err
+	}
+	// the recorded mint transaction must not be replaced
+	pending.SetBumpPolicy(nil)
+	return pending, nil
+}
+
+/**
+BridgeTFCExchangeAsync is the same as BridgeTFCExchangeTx, except that only the hash of the mint transaction is returned.
+*/
+func (tfc *TFC) BridgeTFCExchangeAsync(ctx context.Context, depositTransactionHash string, amount *big.Int, minter *Account, depositTransactionConfirmationRequirement int) (recipient Address, mintTransactionHash string, err error) {
+	recipient, pending, err := tfc.BridgeTFCExchangeTx(ctx, depositTransactionHash, amount, minter, depositTransactionConfirmationRequirement)
+	if pending == nil {
 		return "", "", err
 	}
-	return recipient, tx.Hash().Hex(), nil
+	return recipient, string(pending.Hash()), err
 }
 
 func (tfc *TFC) UntilBridgeTFCExchangeComplete(ctx context.Context, mintTransactionHash string, confirmationRequirement int) (doneCh chan interface{}, errCh chan error) {
-	doneCh = make(chan interface{}, 0)
-	errCh = make(chan error, 0)
+	doneCh = make(chan interface{}, 1)
+	errCh = make(chan error, 1)
 	receiptCh, eCh := tfc.AsyncTransaction(ctx, common.HexToHash(mintTransactionHash), confirmationRequirement)
 	go func() {
 		select {
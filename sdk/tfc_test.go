@@ -84,6 +84,252 @@ func TestTFC_Transfer(t *testing.T) {
 	}
 }
 
+func TestTFC_ApproveAndTransferFrom(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	amount := big.NewInt(1000)
+	owner := PredefinedAccounts[0]
+	spender := PredefinedAccounts[1]
+	recipient := PredefinedAccounts[2]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), owner)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	err = tfc.MintSync(context.Background(), owner.Address(), amount, owner)
+	checkError(t, err)
+
+	err = tfc.ApproveSync(context.Background(), spender.Address(), big.NewInt(600), owner)
+	checkError(t, err)
+	allowance, err := tfc.Allowance(owner.Address(), spender.Address())
+	checkError(t, err)
+	if allowance.Cmp(big.NewInt(600)) != 0 {
+		t.Fatal("approve does not work")
+	}
+
+	err = tfc.TransferFromSync(context.Background(), owner.Address(), recipient.Address(), big.NewInt(400), spender)
+	checkError(t, err)
+
+	balance, err := tfc.BalanceOf(recipient.Address())
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(400)) != 0 {
+		t.Fatal("transferFrom does not work")
+	}
+	balance, err = tfc.BalanceOf(owner.Address())
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(600)) != 0 {
+		t.Fatal("transferFrom does not deduct from owner")
+	}
+	allowance, err = tfc.Allowance(owner.Address(), spender.Address())
+	checkError(t, err)
+	if allowance.Cmp(big.NewInt(200)) != 0 {
+		t.Fatal("transferFrom does not deduct allowance")
+	}
+
+	// transfer more than the remaining allowance
+	err = tfc.TransferFromSync(context.Background(), owner.Address(), recipient.Address(), big.NewInt(201), spender)
+	if err == nil {
+		t.Fatal("transferFrom should fail when exceeding allowance")
+	}
+
+	err = tfc.TransferFromSync(context.Background(), "invalid", recipient.Address(), big.NewInt(1), spender)
+	if err != InvalidAddressError {
+		t.Fatal("invalid address should be rejected")
+	}
+}
+
+func TestTFC_IncreaseDecreaseAllowance(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	owner := PredefinedAccounts[0]
+	spender := PredefinedAccounts[1]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), owner)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	err = tfc.IncreaseAllowanceSync(context.Background(), spender.Address(), big.NewInt(500), owner)
+	checkError(t, err)
+	err = tfc.IncreaseAllowanceSync(context.Background(), spender.Address(), big.NewInt(300), owner)
+	checkError(t, err)
+	allowance, err := tfc.Allowance(owner.Address(), spender.Address())
+	checkError(t, err)
+	if allowance.Cmp(big.NewInt(800)) != 0 {
+		t.Fatal("increaseAllowance does not work")
+	}
+
+	err = tfc.DecreaseAllowanceSync(context.Background(), spender.Address(), big.NewInt(100), owner)
+	checkError(t, err)
+	allowance, err = tfc.Allowance(owner.Address(), spender.Address())
+	checkError(t, err)
+	if allowance.Cmp(big.NewInt(700)) != 0 {
+		t.Fatal("decreaseAllowance does not work")
+	}
+
+	// allowance cannot go below zero
+	err = tfc.DecreaseAllowanceSync(context.Background(), spender.Address(), big.NewInt(701), owner)
+	if err == nil {
+		t.Fatal("decreaseAllowance below zero should fail")
+	}
+}
+
+func TestTFC_RoleManagement(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	admin := PredefinedAccounts[0]
+	minter := PredefinedAccounts[1]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), admin)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	for _, role := range Roles {
+		has, err := tfc.HasRole(role, admin.Address())
+		checkError(t, err)
+		if !has {
+			t.Fatalf("deployer should have %s", role)
+		}
+		roleAdmin, err := tfc.RoleAdmin(role)
+		checkError(t, err)
+		if roleAdmin != AdminRole {
+			t.Fatalf("admin of %s should be %s", role, AdminRole)
+		}
+	}
+
+	err = tfc.GrantRoleSync(context.Background(), MinterRole, minter.Address(), admin)
+	checkError(t, err)
+	members, err := tfc.RoleMembers(MinterRole)
+	checkError(t, err)
+	found := false
+	for _, member := range members {
+		if member == minter.Address() {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatal("grantRole does not work")
+	}
+	err = tfc.MintSync(context.Background(), minter.Address(), big.NewInt(1), minter)
+	checkError(t, err)
+
+	err = tfc.RevokeRoleSync(context.Background(), MinterRole, minter.Address(), admin)
+	checkError(t, err)
+	has, err := tfc.HasRole(MinterRole, minter.Address())
+	checkError(t, err)
+	if has {
+		t.Fatal("revokeRole does not work")
+	}
+
+	// only role admin can grant roles
+	err = tfc.GrantRoleSync(context.Background(), MinterRole, minter.Address(), minter)
+	if err == nil {
+		t.Fatal("grantRole by non-admin should fail")
+	}
+
+	err = tfc.GrantRoleSync(context.Background(), PauserRole, minter.Address(), admin)
+	checkError(t, err)
+	err = tfc.RenounceRoleSync(context.Background(), PauserRole, minter.Address(), minter)
+	checkError(t, err)
+	has, err = tfc.HasRole(PauserRole, minter.Address())
+	checkError(t, err)
+	if has {
+		t.Fatal("renounceRole does not work")
+	}
+
+	_, err = tfc.RoleMembers(Role(100))
+	if err != InvalidRoleError {
+		t.Fatal("invalid role should be rejected")
+	}
+}
+
+func TestTFC_PauseAndBurn(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	address, err := sdk.DeployTFCSync(context.Background(), admin)
+	checkError(t, err)
+	tfc, err := sdk.TFC(address)
+	checkError(t, err)
+
+	err = tfc.MintSync(context.Background(), user.Address(), big.NewInt(1000), admin)
+	checkError(t, err)
+
+	err = tfc.PauseSync(context.Background(), admin)
+	checkError(t, err)
+	paused, err := tfc.IsPaused()
+	checkError(t, err)
+	if !paused {
+		t.Fatal("pause does not work")
+	}
+	err = tfc.TransferSync(context.Background(), admin.Address(), big.NewInt(1), user)
+	if err != TokenPausedErr {
+		t.Fatal("transfer should fail when token is paused", err)
+	}
+	err = tfc.MintSync(context.Background(), user.Address(), big.NewInt(1), admin)
+	if err != TokenPausedErr {
+		t.Fatal("mint should fail when token is paused", err)
+	}
+	err = tfc.BurnSync(context.Background(), big.NewInt(1), user)
+	if err != TokenPausedErr {
+		t.Fatal("burn should fail when token is paused", err)
+	}
+
+	// only pauser can unpause
+	err = tfc.UnpauseSync(context.Background(), user)
+	if err == nil {
+		t.Fatal("unpause by non-pauser should fail")
+	}
+	err = tfc.UnpauseSync(context.Background(), admin)
+	checkError(t, err)
+	paused, err = tfc.IsPaused()
+	checkError(t, err)
+	if paused {
+		t.Fatal("unpause does not work")
+	}
+
+	// only burner can burn
+	err = tfc.BurnSync(context.Background(), big.NewInt(100), user)
+	if err == nil {
+		t.Fatal("burn by non-burner should fail")
+	}
+	err = tfc.GrantRoleSync(context.Background(), BurnerRole, user.Address(), admin)
+	checkError(t, err)
+	err = tfc.BurnSync(context.Background(), big.NewInt(100), user)
+	checkError(t, err)
+	err = tfc.ApproveSync(context.Background(), admin.Address(), big.NewInt(200), user)
+	checkError(t, err)
+	err = tfc.BurnFromSync(context.Background(), user.Address(), big.NewInt(200), admin)
+	checkError(t, err)
+
+	balance, err := tfc.BalanceOf(user.Address())
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(700)) != 0 {
+		t.Fatal("burn does not work")
+	}
+	totalSupply, err := tfc.TotalSupply()
+	checkError(t, err)
+	if totalSupply.Cmp(big.NewInt(700)) != 0 {
+		t.Fatal("burn does not reduce total supply")
+	}
+}
+
 func TestTFC_BridgeTFCExchange(t *testing.T) {
 	erc20ContractAddress := Address("0x401Ef2b876Db2608e4A353800BBaD1E3e3Ea8B46")
 	sdk, err := NewSDK("wss://rinkeby.infura.io/ws/v3/e8e5b9ad18ad4daeb0e01a522a989d66")
@@ -105,13 +351,14 @@ func TestTFC_BridgeTFCExchange(t *testing.T) {
 	amount := new(big.Int)
 	amount.SetString("1000000000000000000", 10)
 
-	requiredTransferAmount, estimatedGas, gasPrice, err := tfcContract.EstimateTFCExchangeFee(context.Background(), recipient, amount, bridgeAccount, 0, 0.1)
+	quote, err := tfcContract.EstimateTFCExchangeFee(context.Background(), recipient, amount, bridgeAccount, 0, 0.1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println("required transfer amount", requiredTransferAmount.Uint64())
-	fmt.Println("estimated gas", estimatedGas)
-	fmt.Println("gas price", gasPrice.Uint64())
+	fmt.Println("quote", quote.ID)
+	fmt.Println("required transfer amount", quote.RequiredTransferAmount.Uint64())
+	fmt.Println("estimated gas", quote.EstimatedGas)
+	fmt.Println("gas price", quote.GasPrice.Uint64())
 
 	depositTransactionHash := "0x37e137a35944045a02c78e994b5332cba9acf62595accd31829c1f1b08ca5ba8"
 	recipient, depositAmount, err := tfcContract.CheckTransactionFeeDeposit(context.Background(), depositTransactionHash, bridgeAccount.Address(), 6)
@@ -123,13 +370,10 @@ func TestTFC_BridgeTFCExchange(t *testing.T) {
 	fmt.Println("deposit amount", depositAmount.Uint64())
 	txHash, err := tfcContract.SendMintTransaction(
 		context.Background(),
-		recipient,
-		amount,
+		quote,
+		depositTransactionHash,
 		bridgeAccount,
 		depositAmount,
-		estimatedGas,
-		gasPrice,
-		0.1,
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -146,3 +390,85 @@ func TestTFC_BridgeTFCExchange(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestTFC_BridgeTFCExchange_adapter(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+
+	// errors of the deposit transaction are returned without channels
+	_, transactionHashErr, doneCh, errCh := tfc.BridgeTFCExchange(ctx, "0x01", big.NewInt(100), bridge, 0)
+	if transactionHashErr != UnknownTransactionHashErr || doneCh != nil || errCh != nil {
+		t.Fatal("expect UnknownTransactionHashErr, got", transactionHashErr)
+	}
+
+	// errors of the mint transaction are fed to errCh with the recipient
+	depositHash := depositTo(t, mockEth.Backend, user, bridge.Address(), big.NewInt(1000))
+	recipient, transactionHashErr, doneCh, errCh := tfc.BridgeTFCExchange(ctx, depositHash, big.NewInt(100), PredefinedAccounts[2], 0)
+	checkError(t, transactionHashErr)
+	if recipient != user.Address() {
+		t.Fatal("recipient should be the sender of the deposit, got", recipient)
+	}
+	select {
+	case <-doneCh:
+		t.Fatal("account without MINTER_ROLE should not mint")
+	case <-errCh:
+	}
+
+	depositHash = depositTo(t, mockEth.Backend, user, bridge.Address(), big.NewInt(1000))
+	recipient, transactionHashErr, doneCh, errCh = tfc.BridgeTFCExchange(ctx, depositHash, big.NewInt(100), bridge, 0)
+	checkError(t, transactionHashErr)
+	select {
+	case <-doneCh:
+	case err := <-errCh:
+		t.Fatal(err)
+	}
+	balance, err := tfc.BalanceOf(recipient)
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(100)) != 0 {
+		t.Fatal("recipient should be minted, got", balance)
+	}
+	if _, transactionHashErr, _, _ := tfc.BridgeTFCExchange(ctx, depositHash, big.NewInt(100), bridge, 0); transactionHashErr != DepositUsedErr {
+		t.Fatal("expect DepositUsedErr, got", transactionHashErr)
+	}
+}
+
+func TestTFC_BridgeTFCExchange_noBump(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	sdk.SetBumpPolicy(&BumpPolicy{Blocks: 2, Percent: 50})
+	tfcAddress, err := sdk.DeployTFCSync(ctx, PredefinedAccounts[0])
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	bridge := PredefinedAccounts[0]
+	user := PredefinedAccounts[1]
+
+	// the mint transactions recorded in the ledger must not be replaced
+	depositHash := depositTo(t, mockEth.Backend, user, bridge.Address(), big.NewInt(1000))
+	_, pending, err := tfc.BridgeTFCExchangeTx(ctx, depositHash, big.NewInt(100), bridge, 0)
+	checkError(t, err)
+	if pending.bumpPolicy != nil {
+		t.Fatal("mint transaction of the deposit should not be bumped")
+	}
+
+	quote, err := tfc.EstimateTFCExchangeFee(ctx, user.Address(), big.NewInt(100), bridge, 0, 0.1)
+	checkError(t, err)
+	depositHash = depositTo(t, mockEth.Backend, user, bridge.Address(), quote.RequiredTransferAmount)
+	pending, err = tfc.SendMintTx(ctx, quote, depositHash, bridge, quote.RequiredTransferAmount)
+	checkError(t, err)
+	if pending.bumpPolicy != nil {
+		t.Fatal("mint transaction of the quote should not be bumped")
+	}
+}
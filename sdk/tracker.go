@@ -0,0 +1,376 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sync"
+)
+
+// ConfirmationStatus is the status of a transaction watched by ConfirmationTracker
+type ConfirmationStatus int
+
+const (
+	// TxPending means the transaction is not mined yet. It is never emitted by ConfirmationTracker.
+	TxPending ConfirmationStatus = iota
+	// TxMined means the transaction is included in a canonical block, but does not have enough confirmations yet
+	TxMined
+	// TxReorged means the block including the transaction has left the canonical chain.
+	// The transaction is still watched and may be mined again.
+	TxReorged
+	// TxConfirmed means the transaction has the required number of confirmations. No more event will be emitted.
+	TxConfirmed
+	// TxDropped means the transaction is neither mined nor pending any more. No more event will be emitted.
+	TxDropped
+)
+
+func (status ConfirmationStatus) String() string {
+	switch status {
+	case TxPending:
+		return "pending"
+	case TxMined:
+		return "mined"
+	case TxReorged:
+		return "reorged"
+	case TxConfirmed:
+		return "confirmed"
+	case TxDropped:
+		return "dropped"
+	default:
+		return "unknown"
+	}
+}
+
+// ConfirmationEvent is the status change of a watched transaction
+type ConfirmationEvent struct {
+	TransactionHash Hash
+	Status          ConfirmationStatus
+	// Receipt is the receipt of the transaction in the (formerly, if reorged) canonical chain, nil if dropped.
+	Receipt       *types.Receipt
+	Confirmations int
+}
+
+// DropAfterBlocks is the default number of new blocks after which a transaction unknown to the node is considered dropped
+var DropAfterBlocks = 6
+
+/**
+ConfirmationTracker watches the confirmation of many transactions with one new head subscription.
+
+On each new block, it fetches the receipts of watched transactions,
+checks whether they are still in the canonical chain,
+and emits Mined, Reorged, Confirmed or Dropped events for each transaction.
+The head subscription is created when the first transaction is watched and released when no transaction is watched.
+*/
+type ConfirmationTracker struct {
+	backend Backend
+
+	// DropAfterBlocks is the number of new blocks after which a transaction unknown to the node is considered dropped
+	DropAfterBlocks int
+
+	lock    sync.Mutex
+	watches map[*txWatch]struct{}
+	sub     ethereum.Subscription
+	quit    chan struct{}
+}
+
+type txWatch struct {
+	ctx           context.Context
+	txHash        common.Hash
+	confirmations int
+
+	eventCh  chan *ConfirmationEvent
+	errCh    chan error
+	finished chan struct{}
+
+	queueLock sync.Mutex
+	queue     []*ConfirmationEvent // events not delivered to eventCh yet, which is unbounded so that no event is dropped
+	queued    chan struct{}        // notifies the delivery goroutine of new events
+
+	lock    sync.Mutex // serializes checks of the transaction
+	done    bool
+	receipt *types.Receipt // receipt in canonical chain, nil if not mined
+	missing int            // number of consecutive checks in which the transaction is unknown
+}
+
+func NewConfirmationTracker(backend Backend) *ConfirmationTracker {
+	return &ConfirmationTracker{
+		backend:         backend,
+		DropAfterBlocks: DropAfterBlocks,
+		watches:         make(map[*txWatch]struct{}),
+	}
+}
+
+/**
+Watch the transaction until it has confirmationNumber confirmations or is dropped.
+
+Status changes of the transaction are fed to eventCh in order, the last of which is either TxConfirmed or TxDropped.
+No event is dropped if the watcher does not keep up, and a slow watcher does not delay the events of other transactions.
+If ctx is done or there is an error, the error will be fed to errCh and the transaction is no longer watched.
+*/
+func (t *ConfirmationTracker) Watch(ctx context.Context, txHash common.Hash, confirmationNumber int) (eventCh chan *ConfirmationEvent, errCh chan error) {
+	w := &txWatch{
+		ctx:           ctx,
+		txHash:        txHash,
+		confirmations: confirmationNumber,
+		eventCh:       make(chan *ConfirmationEvent, 8),
+		errCh:         make(chan error, 1),
+		finished:      make(chan struct{}),
+		queued:        make(chan struct{}, 1),
+	}
+
+	t.lock.Lock()
+	if t.sub == nil {
+		headerCh := make(chan *types.Header, 16)
+		sub, err := t.backend.SubscribeNewHead(context.Background(), headerCh)
+		if err != nil {
+			t.lock.Unlock()
+			w.errCh <- err
+			return w.eventCh, w.errCh
+		}
+		t.sub = sub
+		t.quit = make(chan struct{})
+		go t.loop(sub, headerCh, t.quit)
+	}
+	t.watches[w] = struct{}{}
+	t.lock.Unlock()
+	go w.deliver()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			t.fail(w, ctx.Err())
+		case <-w.finished:
+		}
+	}()
+	// check if the transaction is already confirmed
+	go func() {
+		head, err := t.backend.HeaderByNumber(ctx, nil)
+		if err != nil {
+			t.fail(w, err)
+			return
+		}
+		t.check(w, head, make(map[uint64]common.Hash))
+	}()
+	return w.eventCh, w.errCh
+}
+
+// Watching returns the number of transactions being watched
+func (t *ConfirmationTracker) Watching() int {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return len(t.watches)
+}
+
+func (t *ConfirmationTracker) loop(sub ethereum.Subscription, headerCh chan *types.Header, quit chan struct{}) {
+	for {
+		select {
+		case <-quit:
+			return
+		case err := <-sub.Err():
+			// the subscription is broken, all watches fail
+			t.lock.Lock()
+			if t.quit != quit {
+				t.lock.Unlock()
+				return
+			}
+			t.sub = nil
+			watches := t.watches
+			t.watches = make(map[*txWatch]struct{})
+			t.lock.Unlock()
+			if err == nil {
+				err = SubscriptionClosedErr
+			}
+			for w := range watches {
+				t.fail(w, err)
+			}
+			return
+		case header := <-headerCh:
+			t.lock.Lock()
+			watches := make([]*txWatch, 0, len(t.watches))
+			for w := range t.watches {
+				watches = append(watches, w)
+			}
+			t.lock.Unlock()
+			// canonical block hashes are shared by all watches on this head
+			canonical := make(map[uint64]common.Hash)
+			for _, w := range watches {
+				t.check(w, header, canonical)
+			}
+		}
+	}
+}
+
+func (t *ConfirmationTracker) unwatch(w *txWatch) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.watches, w)
+	if len(t.watches) == 0 && t.sub != nil {
+		t.sub.Unsubscribe()
+		close(t.quit)
+		t.sub = nil
+		t.quit = nil
+	}
+}
+
+func (t *ConfirmationTracker) fail(w *txWatch, err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.done {
+		return
+	}
+	t.failLocked(w, err)
+}
+
+// emit queues the event to be delivered to the watcher, which never blocks, w.lock must be held
+func (t *ConfirmationTracker) emit(w *txWatch, status ConfirmationStatus, receipt *types.Receipt, confirmations int) {
+	event := &ConfirmationEvent{
+		TransactionHash: Hash(w.txHash.Hex()),
+		Status:          status,
+		Receipt:         receipt,
+		Confirmations:   confirmations,
+	}
+	w.queueLock.Lock()
+	w.queue = append(w.queue, event)
+	w.queueLock.Unlock()
+	select {
+	case w.queued <- struct{}{}:
+	default:
+	}
+	if status == TxConfirmed || status == TxDropped {
+		w.done = true
+		close(w.finished)
+		t.unwatch(w)
+	}
+}
+
+// deliver feeds the queued events to eventCh in order, until the last event is delivered, the watch fails or ctx is done,
+// so that a slow watcher never blocks the checks of other transactions
+func (w *txWatch) deliver() {
+	for {
+		w.queueLock.Lock()
+		if len(w.queue) == 0 {
+			w.queueLock.Unlock()
+			select {
+			case <-w.queued:
+				continue
+			case <-w.finished:
+				// the last event is queued before finished is closed
+				w.queueLock.Lock()
+				empty := len(w.queue) == 0
+				w.queueLock.Unlock()
+				if empty {
+					return
+				}
+				continue
+			case <-w.ctx.Done():
+				return
+			}
+		}
+		event := w.queue[0]
+		w.queue = w.queue[1:]
+		w.queueLock.Unlock()
+		select {
+		case w.eventCh <- event:
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+func (t *ConfirmationTracker) canonicalHash(ctx context.Context, number *big.Int, cache map[uint64]common.Hash) (hash common.Hash, err error) {
+	if hash, ok := cache[number.Uint64()]; ok {
+		return hash, nil
+	}
+	header, err := t.backend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return hash, err
+	}
+	cache[number.Uint64()] = header.Hash()
+	return header.Hash(), nil
+}
+
+/**
+Check the status of the watched transaction at the given chain head.
+*/
+func (t *ConfirmationTracker) check(w *txWatch, head *types.Header, canonical map[uint64]common.Hash) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if w.done {
+		return
+	}
+	ctx := w.ctx
+	receipt, err := t.backend.TransactionReceipt(ctx, w.txHash)
+	if err == ethereum.NotFound || (err == nil && receipt == nil) {
+		if w.receipt != nil {
+			// the transaction was mined but the block has been removed from canonical chain
+			t.emit(w, TxReorged, w.receipt, 0)
+			w.receipt = nil
+		}
+		// check if the transaction is still known by the node
+		_, _, err := t.backend.TransactionByHash(ctx, w.txHash)
+		if err == ethereum.NotFound {
+			w.missing++
+			if w.missing > t.DropAfterBlocks {
+				t.emit(w, TxDropped, nil, 0)
+			}
+			return
+		} else if err != nil {
+			t.failLocked(w, err)
+			return
+		}
+		w.missing = 0
+		return
+	} else if err != nil {
+		t.failLocked(w, err)
+		return
+	}
+	w.missing = 0
+
+	hash, err := t.canonicalHash(ctx, receipt.BlockNumber, canonical)
+	if err != nil {
+		t.failLocked(w, err)
+		return
+	}
+	if hash != receipt.BlockHash {
+		// the receipt is in a block which is not in canonical chain
+		if w.receipt != nil {
+			t.emit(w, TxReorged, w.receipt, 0)
+			w.receipt = nil
+		}
+		return
+	}
+	if w.receipt != nil && w.receipt.BlockHash != receipt.BlockHash {
+		// the transaction has been mined in another block after reorg
+		t.emit(w, TxReorged, w.receipt, 0)
+		w.receipt = nil
+	}
+
+	confirmations := 0
+	if head.Number.Cmp(receipt.BlockNumber) > 0 {
+		c := new(big.Int).Sub(head.Number, receipt.BlockNumber)
+		if c.IsInt64() && c.Int64() < int64(^uint32(0)>>1) {
+			confirmations = int(c.Int64())
+		} else {
+			confirmations = int(^uint32(0) >> 1)
+		}
+	}
+	if w.receipt == nil {
+		w.receipt = receipt
+		if confirmations < w.confirmations {
+			t.emit(w, TxMined, receipt, confirmations)
+		}
+	}
+	if confirmations >= w.confirmations {
+		t.emit(w, TxConfirmed, receipt, confirmations)
+	}
+}
+
+// failLocked is the same as fail except that w.lock must be held
+func (t *ConfirmationTracker) failLocked(w *txWatch, err error) {
+	w.done = true
+	w.errCh <- err
+	close(w.finished)
+	t.unwatch(w)
+}
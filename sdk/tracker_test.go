@@ -0,0 +1,225 @@
+package sdk
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+)
+
+// trackedBackend counts head subscriptions and is able to pretend receipts are in orphaned blocks
+type trackedBackend struct {
+	*MockBackend
+	lock          sync.Mutex
+	subscriptions int
+	orphan        bool
+}
+
+func (b *trackedBackend) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	b.lock.Lock()
+	b.subscriptions++
+	b.lock.Unlock()
+	return b.MockBackend.SubscribeNewHead(ctx, ch)
+}
+
+func (b *trackedBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.MockBackend.TransactionReceipt(ctx, txHash)
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.orphan && receipt != nil {
+		orphaned := *receipt
+		orphaned.BlockHash = common.HexToHash("0xdead")
+		return &orphaned, err
+	}
+	return receipt, err
+}
+
+func (b *trackedBackend) setOrphan(orphan bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.orphan = orphan
+}
+
+func nextConfirmationEvent(t *testing.T, eventCh chan *ConfirmationEvent, errCh chan error) *ConfirmationEvent {
+	select {
+	case event := <-eventCh:
+		return event
+	case err := <-errCh:
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("confirmation event is not received")
+	}
+	return nil
+}
+
+func TestConfirmationTracker_SharedSubscription(t *testing.T) {
+	backend := &trackedBackend{MockBackend: NewMockBackend()}
+	tracker := NewConfirmationTracker(backend)
+
+	var eventChs []chan *ConfirmationEvent
+	var errChs []chan error
+	var hashes []common.Hash
+	for i := 0; i < 20; i++ {
+		signedTx := prepareEthTransferTransaction(backend.MockBackend, PredefinedAccounts[0], PredefinedAccounts[1], big.NewInt(1))
+		checkError(t, backend.SendTransaction(context.Background(), signedTx))
+		eventCh, errCh := tracker.Watch(context.Background(), signedTx.Hash(), 2)
+		eventChs = append(eventChs, eventCh)
+		errChs = append(errChs, errCh)
+		hashes = append(hashes, signedTx.Hash())
+	}
+	time.Sleep(100 * time.Millisecond)
+	if tracker.Watching() != 20 {
+		t.Fatal("expect 20 watched transactions, got", tracker.Watching())
+	}
+	for i := 0; i < 3; i++ {
+		backend.Commit()
+		time.Sleep(50 * time.Millisecond)
+	}
+	for i := range eventChs {
+		event := nextConfirmationEvent(t, eventChs[i], errChs[i])
+		if event.Status == TxMined {
+			event = nextConfirmationEvent(t, eventChs[i], errChs[i])
+		}
+		if event.Status != TxConfirmed || event.TransactionHash != Hash(hashes[i].Hex()) || event.Confirmations < 2 {
+			t.Fatal("transaction is not confirmed", event)
+		}
+	}
+	if backend.subscriptions != 1 {
+		t.Fatal("expect 1 head subscription, got", backend.subscriptions)
+	}
+	if tracker.Watching() != 0 {
+		t.Fatal("confirmed transactions should not be watched")
+	}
+}
+
+func TestConfirmationTracker_Dropped(t *testing.T) {
+	backend := NewMockBackend()
+	tracker := NewConfirmationTracker(backend)
+	tracker.DropAfterBlocks = 1
+
+	signedTx := prepareEthTransferTransaction(backend, PredefinedAccounts[0], PredefinedAccounts[1], big.NewInt(1))
+	checkError(t, backend.SendTransaction(context.Background(), signedTx))
+	eventCh, errCh := tracker.Watch(context.Background(), signedTx.Hash(), 0)
+	time.Sleep(50 * time.Millisecond)
+	// the transaction is removed from pending block
+	backend.Rollback()
+	backend.Commit()
+	backend.Commit()
+
+	event := nextConfirmationEvent(t, eventCh, errCh)
+	if event.Status != TxDropped || event.Receipt != nil {
+		t.Fatal("transaction should be dropped", event)
+	}
+
+	// AsyncTransaction reports dropped transaction as error
+	p := NewProvider(backend)
+	p.tracker.DropAfterBlocks = 0
+	receiptCh, eCh := p.AsyncTransaction(context.Background(), signedTx.Hash(), 0)
+	backend.Commit()
+	select {
+	case <-receiptCh:
+		t.Fatal("dropped transaction should not have receipt")
+	case err := <-eCh:
+		if err != TransactionDroppedErr {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("dropped transaction is not reported")
+	}
+}
+
+func TestConfirmationTracker_Reorged(t *testing.T) {
+	backend := &trackedBackend{MockBackend: NewMockBackend()}
+	tracker := NewConfirmationTracker(backend)
+
+	signedTx := prepareEthTransferTransaction(backend.MockBackend, PredefinedAccounts[0], PredefinedAccounts[1], big.NewInt(1))
+	checkError(t, backend.SendTransaction(context.Background(), signedTx))
+	eventCh, errCh := tracker.Watch(context.Background(), signedTx.Hash(), 3)
+	time.Sleep(50 * time.Millisecond)
+
+	backend.Commit()
+	event := nextConfirmationEvent(t, eventCh, errCh)
+	if event.Status != TxMined || event.Receipt.TxHash != signedTx.Hash() {
+		t.Fatal("transaction should be mined", event)
+	}
+	minedBlock := event.Receipt.BlockHash
+
+	// the block including the transaction leaves canonical chain
+	backend.setOrphan(true)
+	backend.Commit()
+	event = nextConfirmationEvent(t, eventCh, errCh)
+	if event.Status != TxReorged || event.Receipt.BlockHash != minedBlock {
+		t.Fatal("transaction should be reorged", event)
+	}
+
+	// the transaction is included in canonical chain again
+	backend.setOrphan(false)
+	backend.Commit()
+	event = nextConfirmationEvent(t, eventCh, errCh)
+	if event.Status != TxMined {
+		t.Fatal("transaction should be mined again", event)
+	}
+	backend.Commit()
+	event = nextConfirmationEvent(t, eventCh, errCh)
+	if event.Status != TxConfirmed || event.Confirmations != 3 {
+		t.Fatal("transaction should be confirmed", event)
+	}
+}
+
+func TestConfirmationTracker_slowWatcher(t *testing.T) {
+	backend := &trackedBackend{MockBackend: NewMockBackend()}
+	tracker := NewConfirmationTracker(backend)
+	commit := func() {
+		backend.Commit()
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	signedTx := prepareEthTransferTransaction(backend.MockBackend, PredefinedAccounts[0], PredefinedAccounts[1], big.NewInt(1))
+	checkError(t, backend.SendTransaction(context.Background(), signedTx))
+	eventCh, errCh := tracker.Watch(context.Background(), signedTx.Hash(), 25)
+	time.Sleep(50 * time.Millisecond)
+	commit()
+	// more events than the buffer of eventCh are emitted while the watcher does not read them
+	for i := 0; i < 10; i++ {
+		backend.setOrphan(true)
+		commit()
+		backend.setOrphan(false)
+		commit()
+	}
+	for i := 0; i < 5; i++ {
+		commit()
+	}
+
+	// the other transaction is confirmed even if the watcher above does not keep up
+	otherTx := prepareEthTransferTransaction(backend.MockBackend, PredefinedAccounts[0], PredefinedAccounts[1], big.NewInt(1))
+	checkError(t, backend.SendTransaction(context.Background(), otherTx))
+	otherEventCh, otherErrCh := tracker.Watch(context.Background(), otherTx.Hash(), 1)
+	time.Sleep(50 * time.Millisecond)
+	commit()
+	commit()
+	event := nextConfirmationEvent(t, otherEventCh, otherErrCh)
+	if event.Status == TxMined {
+		event = nextConfirmationEvent(t, otherEventCh, otherErrCh)
+	}
+	if event.Status != TxConfirmed {
+		t.Fatal("other transaction should be confirmed", event)
+	}
+
+	// no event is dropped
+	for i := 0; i < 21; i++ {
+		expected := TxMined
+		if i%2 == 1 {
+			expected = TxReorged
+		}
+		if event := nextConfirmationEvent(t, eventCh, errCh); event.Status != expected {
+			t.Fatal("event", i, "should be", expected, "got", event.Status)
+		}
+	}
+	if event := nextConfirmationEvent(t, eventCh, errCh); event.Status != TxConfirmed {
+		t.Fatal("transaction should be confirmed", event)
+	}
+}
@@ -0,0 +1,349 @@
+package sdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// name of the struct type of EIP-712 domain
+const eip712DomainType = "EIP712Domain"
+
+/**
+TypedData is structured data to be hashed and signed following EIP-712, in the JSON format of eth_signTypedData_v4.
+Types must declare EIP712Domain, PrimaryType and all struct types referenced by them.
+Values of Domain and Message are:
+integers as *big.Int, int, int64, uint64, json.Number, integral float64 or decimal/hex string;
+addresses as Address, common.Address or hex string; bytes as []byte, hexutil.Bytes or hex string;
+structs as map[string]interface{} and arrays as slices.
+*/
+type TypedData struct {
+	Types       TypedDataTypes         `json:"types"`
+	PrimaryType string                 `json:"primaryType"`
+	Domain      map[string]interface{} `json:"domain"`
+	Message     map[string]interface{} `json:"message"`
+}
+
+/**
+TypedDataTypes maps the name of each struct type to its members.
+*/
+type TypedDataTypes map[string][]TypedDataField
+
+/**
+TypedDataField is a member of an EIP-712 struct type.
+*/
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// UnmarshalJSON decodes numbers as json.Number, so that uint256 values do not lose precision
+func (typedData *TypedData) UnmarshalJSON(data []byte) error {
+	type plain TypedData
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode((*plain)(typedData))
+}
+
+/**
+Hash returns the EIP-712 digest to be signed, i.e. keccak256("\x19\x01" || domainSeparator || hashStruct(message)).
+*/
+func (typedData *TypedData) Hash() (hash common.Hash, err error) {
+	domainSeparator, err := typedData.DomainSeparator()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte("\x19\x01"), domainSeparator.Bytes(), messageHash.Bytes()), nil
+}
+
+/**
+DomainSeparator returns hashStruct of the domain.
+*/
+func (typedData *TypedData) DomainSeparator() (hash common.Hash, err error) {
+	return typedData.HashStruct(eip712DomainType, typedData.Domain)
+}
+
+/**
+HashStruct returns keccak256(typeHash || encodeData(data)) of the struct type.
+*/
+func (typedData *TypedData) HashStruct(structType string, data map[string]interface{}) (hash common.Hash, err error) {
+	encoded, err := typedData.EncodeData(structType, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+/**
+TypeHash returns keccak256 of EncodeType of the struct type.
+*/
+func (typedData *TypedData) TypeHash(structType string) (hash common.Hash, err error) {
+	encoded, err := typedData.EncodeType(structType)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash([]byte(encoded)), nil
+}
+
+/**
+EncodeType returns the encoding of the struct type, e.g. "Mail(Person from,Person to,string contents)Person(string name,address wallet)",
+where the struct types it references are appended in alphabetical order.
+*/
+func (typedData *TypedData) EncodeType(structType string) (encoded string, err error) {
+	if _, ok := typedData.Types[structType]; !ok {
+		return "", fmt.Errorf("%w: undefined struct type %s", InvalidTypedDataErr, structType)
+	}
+	deps := make(map[string]bool)
+	typedData.dependencies(structType, deps)
+	delete(deps, structType)
+	sorted := make([]string, 0, len(deps))
+	for dep := range deps {
+		sorted = append(sorted, dep)
+	}
+	sort.Strings(sorted)
+
+	var builder strings.Builder
+	for _, name := range append([]string{structType}, sorted...) {
+		builder.WriteString(name)
+		builder.WriteString("(")
+		for i, field := range typedData.Types[name] {
+			if i > 0 {
+				builder.WriteString(",")
+			}
+			builder.WriteString(field.Type)
+			builder.WriteString(" ")
+			builder.WriteString(field.Name)
+		}
+		builder.WriteString(")")
+	}
+	return builder.String(), nil
+}
+
+// dependencies collects the struct type and the struct types it references, directly or indirectly
+func (typedData *TypedData) dependencies(structType string, found map[string]bool) {
+	if found[structType] {
+		return
+	}
+	fields, ok := typedData.Types[structType]
+	if !ok {
+		return
+	}
+	found[structType] = true
+	for _, field := range fields {
+		typedData.dependencies(elementType(field.Type), found)
+	}
+}
+
+// elementType strips the array dimensions of the type, e.g. "Person" of "Person[][2]"
+func elementType(typ string) string {
+	if i := strings.Index(typ, "["); i >= 0 {
+		return typ[:i]
+	}
+	return typ
+}
+
+/**
+EncodeData returns typeHash || enc(value_1) || ... || enc(value_n) of the struct type.
+Every member of the struct type must be present in data, and data must not contain anything else.
+*/
+func (typedData *TypedData) EncodeData(structType string, data map[string]interface{}) (encoded []byte, err error) {
+	typeHash, err := typedData.TypeHash(structType)
+	if err != nil {
+		return nil, err
+	}
+	fields := typedData.Types[structType]
+	if len(data) != len(fields) {
+		return nil, fmt.Errorf("%w: %s has %d members but %d values", InvalidTypedDataErr, structType, len(fields), len(data))
+	}
+	encoded = append(encoded, typeHash.Bytes()...)
+	for _, field := range fields {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: missing value of %s.%s", InvalidTypedDataErr, structType, field.Name)
+		}
+		enc, err := typedData.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", structType, field.Name, err)
+		}
+		encoded = append(encoded, enc...)
+	}
+	return encoded, nil
+}
+
+// encodeValue returns the 32-byte encoding of the value of the type
+func (typedData *TypedData) encodeValue(typ string, value interface{}) ([]byte, error) {
+	// arrays are encoded as the hash of the concatenated encodings of the elements
+	if strings.HasSuffix(typ, "]") {
+		i := strings.LastIndex(typ, "[")
+		if i < 0 {
+			return nil, fmt.Errorf("%w: invalid type %s", InvalidTypedDataErr, typ)
+		}
+		elements := reflect.ValueOf(value)
+		if value == nil || (elements.Kind() != reflect.Slice && elements.Kind() != reflect.Array) {
+			return nil, fmt.Errorf("%w: %v is not an array", InvalidTypedDataErr, value)
+		}
+		if length := typ[i+1 : len(typ)-1]; length != "" {
+			if n, err := strconv.Atoi(length); err != nil || n != elements.Len() {
+				return nil, fmt.Errorf("%w: %s has %d elements", InvalidTypedDataErr, typ, elements.Len())
+			}
+		}
+		var encoded []byte
+		for j := 0; j < elements.Len(); j++ {
+			enc, err := typedData.encodeValue(typ[:i], elements.Index(j).Interface())
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, enc...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+	// structs are encoded as hashStruct
+	if _, ok := typedData.Types[typ]; ok {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %v is not a struct of %s", InvalidTypedDataErr, value, typ)
+		}
+		hash, err := typedData.HashStruct(typ, data)
+		if err != nil {
+			return nil, err
+		}
+		return hash.Bytes(), nil
+	}
+	return encodeAtomicValue(typ, value)
+}
+
+// encodeAtomicValue returns the 32-byte encoding of the value of the atomic or dynamic (string and bytes) type
+func encodeAtomicValue(typ string, value interface{}) ([]byte, error) {
+	mismatch := fmt.Errorf("%w: %v is not a valid %s", InvalidTypedDataErr, value, typ)
+	switch {
+	case typ == "string":
+		s, ok := value.(string)
+		if !ok {
+			return nil, mismatch
+		}
+		return crypto.Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, ok := parseTypedBytes(value)
+		if !ok {
+			return nil, mismatch
+		}
+		return crypto.Keccak256(b), nil
+	case typ == "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, mismatch
+		}
+		if b {
+			return math.U256Bytes(big.NewInt(1)), nil
+		}
+		return make([]byte, 32), nil
+	case typ == "address":
+		var address common.Address
+		switch v := value.(type) {
+		case common.Address:
+			address = v
+		case Address:
+			if !v.IsValid() {
+				return nil, mismatch
+			}
+			address = v.address()
+		case string:
+			if !common.IsHexAddress(v) {
+				return nil, mismatch
+			}
+			address = common.HexToAddress(v)
+		default:
+			return nil, mismatch
+		}
+		return common.LeftPadBytes(address.Bytes(), 32), nil
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("%w: unknown type %s", InvalidTypedDataErr, typ)
+		}
+		b, ok := parseTypedBytes(value)
+		if !ok || len(b) > n {
+			return nil, mismatch
+		}
+		return common.RightPadBytes(b, 32), nil
+	case strings.HasPrefix(typ, "uint") || strings.HasPrefix(typ, "int"):
+		signed := strings.HasPrefix(typ, "int")
+		bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("%w: unknown type %s", InvalidTypedDataErr, typ)
+		}
+		integer, ok := parseTypedInteger(value)
+		if !ok {
+			return nil, mismatch
+		}
+		if signed {
+			// -2^(bits-1) <= integer < 2^(bits-1)
+			if integer.Sign() >= 0 && integer.BitLen() >= bits || integer.Sign() < 0 && new(big.Int).Not(integer).BitLen() >= bits {
+				return nil, mismatch
+			}
+		} else if integer.Sign() < 0 || integer.BitLen() > bits {
+			return nil, mismatch
+		}
+		// two's complement of negative integers
+		return math.U256Bytes(new(big.Int).Set(integer)), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown type %s", InvalidTypedDataErr, typ)
+	}
+}
+
+// parseTypedBytes parses bytes given as []byte or hex string
+func parseTypedBytes(value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case []byte:
+		return v, true
+	case hexutil.Bytes:
+		return v, true
+	case string:
+		b, err := hexutil.Decode(v)
+		return b, err == nil
+	default:
+		return nil, false
+	}
+}
+
+// parseTypedInteger parses integers given as Go integers, JSON numbers or decimal/hex strings
+func parseTypedInteger(value interface{}) (*big.Int, bool) {
+	switch v := value.(type) {
+	case *big.Int:
+		return v, v != nil
+	case int:
+		return big.NewInt(int64(v)), true
+	case int64:
+		return big.NewInt(v), true
+	case uint64:
+		return new(big.Int).SetUint64(v), true
+	case json.Number:
+		return new(big.Int).SetString(string(v), 10)
+	case float64:
+		if v != v { // NaN
+			return nil, false
+		}
+		integer, accuracy := new(big.Float).SetFloat64(v).Int(nil)
+		return integer, accuracy == big.Exact
+	case string:
+		if strings.HasPrefix(v, "0x") || strings.HasPrefix(v, "0X") {
+			return new(big.Int).SetString(v[2:], 16)
+		}
+		return new(big.Int).SetString(v, 10)
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,190 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"math/big"
+	"testing"
+)
+
+// the example of EIP-712 (https://eips.ethereum.org/assets/eip-712/Example.js)
+const mailTypedDataJSON = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedData_referenceExample(t *testing.T) {
+	var typedData TypedData
+	checkError(t, json.Unmarshal([]byte(mailTypedDataJSON), &typedData))
+
+	encodedType, err := typedData.EncodeType("Mail")
+	checkError(t, err)
+	if encodedType != "Mail(Person from,Person to,string contents)Person(string name,address wallet)" {
+		t.Fatal("encoded type is incorrect", encodedType)
+	}
+	typeHash, err := typedData.TypeHash("Mail")
+	checkError(t, err)
+	if typeHash.Hex() != "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2" {
+		t.Fatal("type hash is incorrect", typeHash.Hex())
+	}
+	domainSeparator, err := typedData.DomainSeparator()
+	checkError(t, err)
+	if domainSeparator.Hex() != "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f" {
+		t.Fatal("domain separator is incorrect", domainSeparator.Hex())
+	}
+	messageHash, err := typedData.HashStruct("Mail", typedData.Message)
+	checkError(t, err)
+	if messageHash.Hex() != "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e" {
+		t.Fatal("struct hash is incorrect", messageHash.Hex())
+	}
+	hash, err := typedData.Hash()
+	checkError(t, err)
+	if hash.Hex() != "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2" {
+		t.Fatal("hash is incorrect", hash.Hex())
+	}
+
+	// signed by the key of Cow
+	key, err := crypto.ToECDSA(crypto.Keccak256([]byte("cow")))
+	checkError(t, err)
+	signature, err := NewKeySigner(key).SignTypedData(context.Background(), &typedData)
+	checkError(t, err)
+	expected := "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d" +
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562" + "1c"
+	if hexutil.Encode(signature) != expected {
+		t.Fatal("signature is incorrect", hexutil.Encode(signature))
+	}
+	signer, err := recoverTypedDataSigner(&typedData, signature)
+	checkError(t, err)
+	if signer != common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826") {
+		t.Fatal("recovered signer is incorrect", signer.Hex())
+	}
+}
+
+func TestTypedData_arrays(t *testing.T) {
+	// the types of the reference example extended with arrays, bytes and integers, cross-checked against go-ethereum
+	types := TypedDataTypes{
+		"EIP712Domain": {{Name: "name", Type: "string"}, {Name: "chainId", Type: "uint256"}},
+		"Person":       {{Name: "name", Type: "string"}, {Name: "wallets", Type: "address[]"}},
+		"Mail": {
+			{Name: "from", Type: "Person"},
+			{Name: "to", Type: "Person[]"},
+			{Name: "contents", Type: "string"},
+			{Name: "attachment", Type: "bytes"},
+			{Name: "digest", Type: "bytes32"},
+			{Name: "priority", Type: "int8"},
+			{Name: "flags", Type: "bool[]"},
+		},
+	}
+	domain := map[string]interface{}{"name": "Ether Mail", "chainId": "0x1"}
+	message := map[string]interface{}{
+		"from": map[string]interface{}{
+			"name":    "Cow",
+			"wallets": []interface{}{"0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"},
+		},
+		"to": []interface{}{
+			map[string]interface{}{"name": "Bob", "wallets": []interface{}{"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"}},
+		},
+		"contents":   "Hello, Bob!",
+		"attachment": "0x0102",
+		"digest":     crypto.Keccak256Hash([]byte("digest")).Hex(),
+		"priority":   "-3",
+		"flags":      []interface{}{true, false},
+	}
+	typedData := &TypedData{Types: types, PrimaryType: "Mail", Domain: domain, Message: message}
+	hash, err := typedData.Hash()
+	checkError(t, err)
+
+	reference := apitypes.TypedData{
+		Types:       apitypes.Types{},
+		PrimaryType: "Mail",
+		Domain:      apitypes.TypedDataDomain{Name: "Ether Mail", ChainId: math256(1)},
+		Message:     message,
+	}
+	for name, fields := range types {
+		for _, field := range fields {
+			reference.Types[name] = append(reference.Types[name], apitypes.Type{Name: field.Name, Type: field.Type})
+		}
+	}
+	expected, _, err := apitypes.TypedDataAndHash(reference)
+	checkError(t, err)
+	if hash.Hex() != hexutil.Encode(expected) {
+		t.Fatal("hash is different from the one of go-ethereum", hash.Hex(), hexutil.Encode(expected))
+	}
+
+	// invalid values are rejected
+	for field, value := range map[string]interface{}{
+		"priority":   "128",
+		"flags":      []interface{}{1},
+		"digest":     "0x" + common.Bytes2Hex(make([]byte, 33)),
+		"attachment": 1,
+		"from":       "Cow",
+	} {
+		invalid := make(map[string]interface{})
+		for k, v := range message {
+			invalid[k] = v
+		}
+		invalid[field] = value
+		typedData.Message = invalid
+		if _, err := typedData.Hash(); !errors.Is(err, InvalidTypedDataErr) {
+			t.Fatal("expect InvalidTypedDataErr for", field, "got", err)
+		}
+	}
+	// fixed-size arrays must have exactly the declared number of elements
+	typedData.Types["Mail"][6].Type = "bool[3]"
+	typedData.Message = message
+	if _, err := typedData.Hash(); !errors.Is(err, InvalidTypedDataErr) {
+		t.Fatal("expect InvalidTypedDataErr, got", err)
+	}
+	typedData.Types["Mail"][6].Type = "bool[2]"
+	_, err = typedData.Hash()
+	checkError(t, err)
+
+	// missing and extra values are rejected
+	typedData.Message = map[string]interface{}{"contents": "Hello, Bob!"}
+	if _, err := typedData.Hash(); !errors.Is(err, InvalidTypedDataErr) {
+		t.Fatal("expect InvalidTypedDataErr, got", err)
+	}
+	typedData.Message = message
+	typedData.Domain = map[string]interface{}{"name": "Ether Mail", "chainId": 1, "salt": "0x00"}
+	if _, err := typedData.Hash(); !errors.Is(err, InvalidTypedDataErr) {
+		t.Fatal("expect InvalidTypedDataErr, got", err)
+	}
+}
+
+// math256 returns the integer as the type of chainId of go-ethereum
+func math256(n int64) *math.HexOrDecimal256 {
+	return (*math.HexOrDecimal256)(big.NewInt(n))
+}
@@ -2,11 +2,11 @@ package sdk
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"math/big"
 	"strings"
@@ -18,44 +18,75 @@ type Backend interface {
 	ethereum.ChainStateReader
 	ethereum.TransactionReader
 	NetworkID(ctx context.Context) (*big.Int, error)
+	ChainID(ctx context.Context) (*big.Int, error)
 }
 
+/**
+Account is an Ethereum account whose transactions and messages are signed by its Signer.
+*/
 type Account struct {
-	address    common.Address
-	privateKey *ecdsa.PrivateKey
-	publicKey  *ecdsa.PublicKey
+	address common.Address
+	signer  Signer
+}
+
+/**
+Creates an Account which signs with the signer, e.g. a KeystoreSigner or a RemoteSigner.
+*/
+func NewAccount(signer Signer) *Account {
+	return &Account{
+		address: signer.Address().address(),
+		signer:  signer,
+	}
 }
 
 func retrieveAccount(privateKey string) (account *Account, err error) {
 	if strings.HasPrefix(privateKey, "0x") {
 		privateKey = privateKey[2:]
 	}
-	acc := &Account{}
-	acc.privateKey, err = crypto.HexToECDSA(privateKey)
+	key, err := crypto.HexToECDSA(privateKey)
 	if err != nil {
 		return nil, InvalidPrivateKeyError
 	}
-	var ok bool
-	acc.publicKey, ok = acc.privateKey.Public().(*ecdsa.PublicKey)
-	if !ok {
-		return nil, InvalidPrivateKeyError
-	}
-	acc.address = crypto.PubkeyToAddress(*acc.publicKey)
-	return acc, nil
+	return NewAccount(NewKeySigner(key)), nil
 }
 
 func createAccount() (account *Account) {
 	privateKey, _ := crypto.GenerateKey()
-	account, _ = retrieveAccount(hexutil.Encode(crypto.FromECDSA(privateKey)))
-	return account
+	return NewAccount(NewKeySigner(privateKey))
 }
 
 func (acc *Account) Address() Address {
 	return Address(acc.address.Hex())
 }
 
+// Signer returns the Signer of the account
+func (acc *Account) Signer() Signer {
+	return acc.signer
+}
+
+/**
+Returns the 0x-prefixed hex private key of the account, which is empty if the key is not held in memory (see KeySigner).
+*/
 func (acc *Account) PrivateKey() string {
-	return string(crypto.FromECDSA(acc.privateKey))
+	keySigner, ok := acc.signer.(*KeySigner)
+	if !ok {
+		return ""
+	}
+	return hexutil.Encode(crypto.FromECDSA(keySigner.privateKey))
+}
+
+// transactor returns the TransactOpts which signs transactions of the account for the chain with chainID
+func (acc *Account) transactor(ctx context.Context, chainID *big.Int) *bind.TransactOpts {
+	return &bind.TransactOpts{
+		From:    acc.address,
+		Context: ctx,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != acc.address {
+				return nil, bind.ErrNotAuthorized
+			}
+			return acc.signer.SignTx(ctx, tx, chainID)
+		},
+	}
 }
 
 type Hash string
@@ -69,3 +100,31 @@ func (addr Address) IsValid() bool {
 func (addr Address) address() common.Address {
 	return common.HexToAddress(string(addr))
 }
+
+// Role is an access control role of the TFC ERC20 contract
+type Role int
+
+const (
+	AdminRole Role = iota
+	MinterRole
+	PauserRole
+	BurnerRole
+)
+
+// Roles lists all roles defined in TFC ERC20 contract
+var Roles = []Role{AdminRole, MinterRole, PauserRole, BurnerRole}
+
+func (role Role) String() string {
+	switch role {
+	case AdminRole:
+		return "DEFAULT_ADMIN_ROLE"
+	case MinterRole:
+		return "MINTER_ROLE"
+	case PauserRole:
+		return "PAUSER_ROLE"
+	case BurnerRole:
+		return "BURNER_ROLE"
+	default:
+		return "UNKNOWN_ROLE"
+	}
+}
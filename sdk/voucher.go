@@ -0,0 +1,247 @@
+package sdk
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"time"
+)
+
+// ClaimVoucherValidity is how long a voucher issued by IssueClaimVoucher is valid
+var ClaimVoucherValidity = 24 * time.Hour
+
+// version of the compact encoding of ClaimVoucher
+const claimVoucherVersion = 1
+
+// length of the compact encoding: version, manager, recipient, amount, nonce, expiry and signature
+const claimVoucherLength = 1 + 20 + 20 + 32 + 32 + 8 + 65
+
+/**
+ClaimVoucher bundles a TFC claim signed by SignTFCClaim with everything needed to redeem it, so that it can be handed to the recipient as a whole.
+It can be serialized to JSON, or to a compact URL-safe string (see Encode) which fits in a QR code.
+Expiry (unix seconds, zero means never) is advisory: it is checked by the SDK only, since it is neither signed nor enforced by the Manager contract,
+so the holder of the voucher can still redeem it after the expiry, e.g. by calling ClaimTFC with its signature.
+To invalidate a voucher for sure, revoke it on chain by RevokeClaimVoucher (or ClaimIssuer.Revoke), which spends its nonce.
+*/
+type ClaimVoucher struct {
+	Manager   Address       `json:"manager"`
+	Recipient Address       `json:"recipient"`
+	Amount    *big.Int      `json:"amount"`
+	Nonce     *big.Int      `json:"nonce"`
+	Expiry    int64         `json:"expiry"`
+	Signature hexutil.Bytes `json:"signature"`
+}
+
+// Expired returns true if the voucher is expired at the time, which does not stop the voucher from being redeemed on chain
+func (voucher *ClaimVoucher) Expired(now time.Time) bool {
+	return voucher.Expiry != 0 && now.Unix() > voucher.Expiry
+}
+
+// validate checks that the fields are well-formed, which does not verify the signature (see VerifyTFCClaim)
+func (voucher *ClaimVoucher) validate() error {
+	if !voucher.Manager.IsValid() || !voucher.Recipient.IsValid() {
+		return InvalidVoucherErr
+	}
+	if voucher.Amount == nil || voucher.Amount.Sign() < 0 || voucher.Amount.BitLen() > 256 {
+		return InvalidVoucherErr
+	}
+	if voucher.Nonce == nil || voucher.Nonce.Sign() < 0 || voucher.Nonce.BitLen() > 256 {
+		return InvalidVoucherErr
+	}
+	if voucher.Expiry < 0 || len(voucher.Signature) != 65 {
+		return InvalidVoucherErr
+	}
+	return nil
+}
+
+/**
+Encode the voucher as a compact URL-safe string (unpadded base64url of the fixed-length binary fields), e.g. as a QR code payload.
+*/
+func (voucher *ClaimVoucher) Encode() (encoded string, err error) {
+	if err := voucher.validate(); err != nil {
+		return "", err
+	}
+	data := make([]byte, 0, claimVoucherLength)
+	data = append(data, claimVoucherVersion)
+	data = append(data, voucher.Manager.address().Bytes()...)
+	data = append(data, voucher.Recipient.address().Bytes()...)
+	data = append(data, common.LeftPadBytes(voucher.Amount.Bytes(), 32)...)
+	data = append(data, common.LeftPadBytes(voucher.Nonce.Bytes(), 32)...)
+	expiry := make([]byte, 8)
+	binary.BigEndian.PutUint64(expiry, uint64(voucher.Expiry))
+	data = append(data, expiry...)
+	data = append(data, voucher.Signature...)
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+/**
+Parse the voucher encoded by Encode. InvalidVoucherErr is returned if it is malformed.
+*/
+func ParseClaimVoucher(encoded string) (voucher *ClaimVoucher, err error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(data) != claimVoucherLength || data[0] != claimVoucherVersion {
+		return nil, InvalidVoucherErr
+	}
+	data = data[1:]
+	voucher = &ClaimVoucher{
+		Manager:   Address(common.BytesToAddress(data[:20]).Hex()),
+		Recipient: Address(common.BytesToAddress(data[20:40]).Hex()),
+		Amount:    new(big.Int).SetBytes(data[40:72]),
+		Nonce:     new(big.Int).SetBytes(data[72:104]),
+		Expiry:    int64(binary.BigEndian.Uint64(data[104:112])),
+		Signature: common.CopyBytes(data[112:]),
+	}
+	if err := voucher.validate(); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+/**
+Parse the voucher serialized as JSON. InvalidVoucherErr is returned if it is malformed.
+*/
+func ParseClaimVoucherJSON(data []byte) (voucher *ClaimVoucher, err error) {
+	voucher = &ClaimVoucher{}
+	if err := json.Unmarshal(data, voucher); err != nil {
+		return nil, InvalidVoucherErr
+	}
+	if err := voucher.validate(); err != nil {
+		return nil, err
+	}
+	return voucher, nil
+}
+
+/**
+Issue a voucher of a TFC claim signed by signer, which must be the signer of TFC Manager contract (see SignTFCClaim).
+The nonce is reserved by the NonceAllocator of the manager until the voucher is redeemed (see ReservePending), and the voucher expires after ClaimVoucherValidity.
+Note that the expiry is not enforced by the contract, so the reservation is kept after the voucher expires,
+and the voucher should be revoked by RevokeClaimVoucher if it must not be redeemed any more.
+*/
+func (manager *Manager) IssueClaimVoucher(ctx context.Context, recipient Address, amount *big.Int, signer *Account) (voucher *ClaimVoucher, err error) {
+	if !recipient.IsValid() {
+		return nil, InvalidAddressError
+	}
+	allocator := manager.NonceAllocator()
+	nonce, err := allocator.ReservePending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := manager.SignTFCClaim(recipient, amount, nonce, signer)
+	if err != nil {
+		_ = allocator.Release(nonce)
+		return nil, err
+	}
+	if err := allocator.HandOut(nonce); err != nil {
+		return nil, err
+	}
+	return &ClaimVoucher{
+		Manager:   Address(manager.address.Hex()),
+		Recipient: recipient,
+		Amount:    amount,
+		Nonce:     nonce,
+		Expiry:    time.Now().Add(ClaimVoucherValidity).Unix(),
+		Signature: hexutil.MustDecode(signature),
+	}, nil
+}
+
+/**
+Claim TFC with the voucher, which is sent by its recipient.
+VoucherMismatchErr is returned if the voucher is addressed to another Manager contract or the claimer is not its recipient,
+VoucherExpiredErr if it is expired, and InvalidSignatureErr or NonceUsedErr if it would be rejected by the contract (see VerifyTFCClaim).
+*/
+func (manager *Manager) ClaimTFCWithVoucherTx(ctx context.Context, voucher *ClaimVoucher, claimer *Account) (pending *PendingTx, err error) {
+	if err := voucher.validate(); err != nil {
+		return nil, err
+	}
+	if voucher.Manager.address() != manager.address || voucher.Recipient.address() != claimer.address {
+		return nil, VoucherMismatchErr
+	}
+	if voucher.Expired(time.Now()) {
+		return nil, VoucherExpiredErr
+	}
+	signature := hexutil.Encode(voucher.Signature)
+	verdict, err := manager.VerifyTFCClaim(voucher.Recipient, voucher.Amount, voucher.Nonce, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !verdict.ValidSignature {
+		return nil, InvalidSignatureErr
+	}
+	if verdict.NonceUsed {
+		return nil, NonceUsedErr
+	}
+	return manager.ClaimTFCTx(ctx, voucher.Amount, voucher.Nonce, signature, claimer)
+}
+
+/**
+ClaimTFCWithVoucher is the same as ClaimTFCWithVoucherTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (manager *Manager) ClaimTFCWithVoucher(ctx context.Context, voucher *ClaimVoucher, claimer *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := manager.ClaimTFCWithVoucherTx(ctx, voucher, claimer)
+	return asyncDone(ctx, pending, err)
+}
+
+func (manager *Manager) ClaimTFCWithVoucherSync(ctx context.Context, voucher *ClaimVoucher, claimer *Account) (err error) {
+	doneCh, errCh := manager.ClaimTFCWithVoucher(ctx, voucher, claimer)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
+
+/**
+Revoke the unused voucher by spending its nonce through a claim of zero TFC to the signer itself, which is sent by the signer.
+signer must be the signer of TFC Manager contract which signed the voucher, otherwise the self-claim is reverted.
+The nonce is not released, and its reservation is dropped once the self-claim is seen on chain (see ClaimNonceAllocator).
+VoucherMismatchErr is returned if the voucher is addressed to another Manager contract, and NonceUsedErr if it has been redeemed or revoked.
+*/
+func (manager *Manager) RevokeClaimVoucherTx(ctx context.Context, voucher *ClaimVoucher, signer *Account) (pending *PendingTx, err error) {
+	if err := voucher.validate(); err != nil {
+		return nil, err
+	}
+	if voucher.Manager.address() != manager.address {
+		return nil, VoucherMismatchErr
+	}
+	used, err := manager.IsNonceUsed(voucher.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	if used {
+		return nil, NonceUsedErr
+	}
+	return manager.spendNonceTx(ctx, voucher.Nonce, signer)
+}
+
+// spendNonceTx sends a claim of zero TFC to the signer itself with the nonce, so that no other claim of the nonce can be redeemed
+func (manager *Manager) spendNonceTx(ctx context.Context, nonce *big.Int, signer *Account) (pending *PendingTx, err error) {
+	zero := big.NewInt(0)
+	signature, err := manager.SignTFCClaim(signer.Address(), zero, nonce, signer)
+	if err != nil {
+		return nil, err
+	}
+	return manager.ClaimTFCTx(ctx, zero, nonce, signature, signer)
+}
+
+/**
+RevokeClaimVoucher is the same as RevokeClaimVoucherTx, except that the confirmation of the transaction is notified via channels.
+*/
+func (manager *Manager) RevokeClaimVoucher(ctx context.Context, voucher *ClaimVoucher, signer *Account) (doneCh chan interface{}, errCh chan error) {
+	pending, err := manager.RevokeClaimVoucherTx(ctx, voucher, signer)
+	return asyncDone(ctx, pending, err)
+}
+
+func (manager *Manager) RevokeClaimVoucherSync(ctx context.Context, voucher *ClaimVoucher, signer *Account) (err error) {
+	doneCh, errCh := manager.RevokeClaimVoucher(ctx, voucher, signer)
+	select {
+	case <-doneCh:
+		return nil
+	case err := <-errCh:
+		return err
+	}
+}
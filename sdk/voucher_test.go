@@ -0,0 +1,178 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClaimVoucher_encoding(t *testing.T) {
+	voucher := &ClaimVoucher{
+		Manager:   PredefinedAccounts[0].Address(),
+		Recipient: PredefinedAccounts[1].Address(),
+		Amount:    new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil),
+		Nonce:     big.NewInt(42),
+		Expiry:    1700000000,
+		Signature: make([]byte, 65),
+	}
+	voucher.Signature[64] = 27
+
+	encoded, err := voucher.Encode()
+	checkError(t, err)
+	if strings.ContainsAny(encoded, "+/=") {
+		t.Fatal("encoded voucher is not URL-safe", encoded)
+	}
+	parsed, err := ParseClaimVoucher(encoded)
+	checkError(t, err)
+	if parsed.Manager != voucher.Manager || parsed.Recipient != voucher.Recipient || parsed.Amount.Cmp(voucher.Amount) != 0 ||
+		parsed.Nonce.Cmp(voucher.Nonce) != 0 || parsed.Expiry != voucher.Expiry || string(parsed.Signature) != string(voucher.Signature) {
+		t.Fatal("parsed voucher is different", parsed)
+	}
+
+	data, err := json.Marshal(voucher)
+	checkError(t, err)
+	parsed, err = ParseClaimVoucherJSON(data)
+	checkError(t, err)
+	if parsed.Nonce.Cmp(voucher.Nonce) != 0 || string(parsed.Signature) != string(voucher.Signature) {
+		t.Fatal("parsed voucher is different", parsed)
+	}
+
+	// malformed vouchers are rejected
+	for _, malformed := range []string{"", "not base64!", encoded[:len(encoded)-4], "B" + encoded[1:]} {
+		if _, err := ParseClaimVoucher(malformed); err != InvalidVoucherErr {
+			t.Fatal("expect InvalidVoucherErr, got", err)
+		}
+	}
+	for _, malformed := range []string{
+		`not json`,
+		`{"manager":"0x01","recipient":"` + string(voucher.Recipient) + `","amount":1,"nonce":1,"expiry":0,"signature":"0x00"}`,
+		strings.Replace(string(data), `"nonce":42`, `"nonce":-1`, 1),
+		strings.Replace(string(data), `"amount":1000000000000000000,`, ``, 1),
+	} {
+		if _, err := ParseClaimVoucherJSON([]byte(malformed)); err != InvalidVoucherErr {
+			t.Fatal("expect InvalidVoucherErr, got", err, malformed)
+		}
+	}
+}
+
+func TestManager_ClaimTFCWithVoucher(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+	otherAddress, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	other, err := sdk.Manager(otherAddress)
+	checkError(t, err)
+
+	voucher, err := manager.IssueClaimVoucher(ctx, user.Address(), big.NewInt(5), admin)
+	checkError(t, err)
+	if voucher.Manager != address || voucher.Expiry == 0 {
+		t.Fatal("voucher is incorrect", voucher)
+	}
+	// the voucher is handed to the recipient as a string
+	encoded, err := voucher.Encode()
+	checkError(t, err)
+	received, err := ParseClaimVoucher(encoded)
+	checkError(t, err)
+
+	if err := other.ClaimTFCWithVoucherSync(ctx, received, user); err != VoucherMismatchErr {
+		t.Fatal("voucher of another manager should be refused, got", err)
+	}
+	if err := manager.ClaimTFCWithVoucherSync(ctx, received, PredefinedAccounts[3]); err != VoucherMismatchErr {
+		t.Fatal("voucher of another recipient should be refused, got", err)
+	}
+	expired := *received
+	expired.Expiry = 1
+	if err := manager.ClaimTFCWithVoucherSync(ctx, &expired, user); err != VoucherExpiredErr {
+		t.Fatal("expect VoucherExpiredErr, got", err)
+	}
+	altered := *received
+	altered.Amount = big.NewInt(500)
+	if err := manager.ClaimTFCWithVoucherSync(ctx, &altered, user); err != InvalidSignatureErr {
+		t.Fatal("expect InvalidSignatureErr, got", err)
+	}
+
+	checkError(t, manager.ClaimTFCWithVoucherSync(ctx, received, user))
+	tfcAddress, err := manager.TFCAddress()
+	checkError(t, err)
+	tfc, err := sdk.TFC(tfcAddress)
+	checkError(t, err)
+	balance, err := tfc.BalanceOf(user.Address())
+	checkError(t, err)
+	if balance.Cmp(big.NewInt(5)) != 0 {
+		t.Fatal("voucher is not claimed")
+	}
+	if err := manager.ClaimTFCWithVoucherSync(ctx, received, user); err != NonceUsedErr {
+		t.Fatal("expect NonceUsedErr, got", err)
+	}
+}
+
+func TestManager_RevokeClaimVoucher(t *testing.T) {
+	mockEth := NewMockEthereum()
+	mockEth.Start()
+	defer mockEth.Stop()
+
+	ctx := context.Background()
+	sdk := NewSDKWithBackend(mockEth.Backend)
+	admin := PredefinedAccounts[0]
+	user := PredefinedAccounts[2]
+	address, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	manager, err := sdk.Manager(address)
+	checkError(t, err)
+	otherAddress, err := sdk.DeployManagerSync(ctx, admin)
+	checkError(t, err)
+	other, err := sdk.Manager(otherAddress)
+	checkError(t, err)
+
+	ClaimVoucherValidity = -time.Second
+	voucher, err := manager.IssueClaimVoucher(ctx, user.Address(), big.NewInt(5), admin)
+	ClaimVoucherValidity = 24 * time.Hour
+	checkError(t, err)
+	if err := manager.ClaimTFCWithVoucherSync(ctx, voucher, user); err != VoucherExpiredErr {
+		t.Fatal("expect VoucherExpiredErr, got", err)
+	}
+	// the expiry is advisory, the expired voucher can still be redeemed on chain
+	verdict, err := manager.VerifyTFCClaim(voucher.Recipient, voucher.Amount, voucher.Nonce, hexutil.Encode(voucher.Signature))
+	checkError(t, err)
+	if !verdict.Claimable() {
+		t.Fatal("expired voucher should be claimable on chain until revoked")
+	}
+
+	if err := other.RevokeClaimVoucherSync(ctx, voucher, admin); err != VoucherMismatchErr {
+		t.Fatal("expect VoucherMismatchErr, got", err)
+	}
+	checkError(t, manager.RevokeClaimVoucherSync(ctx, voucher, admin))
+	verdict, err = manager.VerifyTFCClaim(voucher.Recipient, voucher.Amount, voucher.Nonce, hexutil.Encode(voucher.Signature))
+	checkError(t, err)
+	if verdict.Claimable() {
+		t.Fatal("revoked voucher should not be claimable")
+	}
+	unexpired := *voucher
+	unexpired.Expiry = 0
+	if err := manager.ClaimTFCWithVoucherSync(ctx, &unexpired, user); err != NonceUsedErr {
+		t.Fatal("revoked voucher should not be redeemed, got", err)
+	}
+	if err := manager.RevokeClaimVoucherSync(ctx, voucher, admin); err != NonceUsedErr {
+		t.Fatal("expect NonceUsedErr, got", err)
+	}
+	// the reservation of the revoked nonce is dropped once the self-claim is seen
+	nonce, err := manager.GetUnusedNonce()
+	checkError(t, err)
+	if nonce.Cmp(voucher.Nonce) == 0 || len(manager.NonceAllocator().Reserved()) != 1 {
+		t.Fatal("revoked nonce should be spent, got", nonce, manager.NonceAllocator().Reserved())
+	}
+}
@@ -0,0 +1,205 @@
+package sdk
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/tyler-smith/go-bip39"
+	"math/big"
+)
+
+// scrypt parameters of the keystore JSON exported by Account.ExportKeystore
+var (
+	KeystoreScryptN = keystore.StandardScryptN
+	KeystoreScryptP = keystore.StandardScryptP
+)
+
+/**
+Export the account as Web3 Secret Storage JSON (i.e. a keystore file of geth) encrypted with passphrase.
+It is only possible if the key of the account is available in process, i.e. the Signer is a KeySigner or KeystoreSigner.
+*/
+func (acc *Account) ExportKeystore(passphrase string) (keyJSON []byte, err error) {
+	var privateKey *ecdsa.PrivateKey
+	switch signer := acc.signer.(type) {
+	case *KeySigner:
+		privateKey = signer.privateKey
+	case *KeystoreSigner:
+		key, err := keystore.DecryptKey(signer.keyJSON, signer.passphrase)
+		if err != nil {
+			return nil, err
+		}
+		defer wipeKey(key.PrivateKey)
+		privateKey = key.PrivateKey
+	default:
+		return nil, PrivateKeyUnavailableErr
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    acc.address,
+		PrivateKey: privateKey,
+	}
+	return keystore.EncryptKey(key, passphrase, KeystoreScryptN, KeystoreScryptP)
+}
+
+/**
+Import the account from Web3 Secret Storage JSON encrypted with passphrase.
+The decrypted key is held in memory, use LoadKeystoreSigner instead to keep it encrypted.
+*/
+func importAccount(keyJSON []byte, passphrase string) (account *Account, err error) {
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(NewKeySigner(key.PrivateKey)), nil
+}
+
+/**
+Generates a new BIP-39 mnemonic of 12 words.
+*/
+func NewMnemonic() (mnemonic string, err error) {
+	entropy, err := bip39.NewEntropy(128)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+/**
+Derive the account with index from the BIP-39 mnemonic along the BIP-44 path m/44'/60'/0'/0/index,
+which is the same as wallets like MetaMask.
+*/
+func deriveAccount(mnemonic string, index uint32) (account *Account, err error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, InvalidMnemonicErr
+	}
+	path := make(accounts.DerivationPath, len(accounts.DefaultBaseDerivationPath))
+	copy(path, accounts.DefaultBaseDerivationPath)
+	path[len(path)-1] = index
+	privateKey, err := deriveKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return NewAccount(NewKeySigner(privateKey)), nil
+}
+
+/**
+Derive the private key along the path from the seed, following BIP-32.
+*/
+func deriveKey(seed []byte, path accounts.DerivationPath) (privateKey *ecdsa.PrivateKey, err error) {
+	key, err := deriveExtendedKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return key.privateKey()
+}
+
+/**
+Derive the extended public key of m/44'/60'/0'/0 from the BIP-39 mnemonic,
+whose child with index is the account derived by deriveAccount with the same index.
+*/
+func deriveAccountXPub(mnemonic string) (xpub *ExtendedPublicKey, err error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, InvalidMnemonicErr
+	}
+	path := accounts.DefaultBaseDerivationPath[:len(accounts.DefaultBaseDerivationPath)-1]
+	key, err := deriveExtendedKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return key.neuter()
+}
+
+// extendedKey is a BIP-32 extended private key
+type extendedKey struct {
+	key               *big.Int
+	chainCode         []byte
+	depth             byte
+	parentFingerprint [4]byte
+	childNumber       uint32
+}
+
+/**
+Derive the extended private key along the path from the seed, following BIP-32.
+*/
+func deriveExtendedKey(seed []byte, path accounts.DerivationPath) (key *extendedKey, err error) {
+	n := crypto.S256().Params().N
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key = &extendedKey{key: new(big.Int).SetBytes(sum[:32]), chainCode: sum[32:]}
+	if key.key.Sign() == 0 || key.key.Cmp(n) >= 0 {
+		return nil, InvalidMnemonicErr
+	}
+	for _, index := range path {
+		parent, err := key.privateKey()
+		if err != nil {
+			return nil, err
+		}
+		var data []byte
+		if index >= 0x80000000 {
+			// hardened child
+			data = append([]byte{0}, math.PaddedBigBytes(key.key, 32)...)
+		} else {
+			data = crypto.CompressPubkey(&parent.PublicKey)
+		}
+		tweak, chainCode := childTweak(key.chainCode, data, index)
+		if tweak.Cmp(n) >= 0 {
+			return nil, InvalidDerivationErr
+		}
+		child := tweak.Add(tweak, key.key).Mod(tweak, n)
+		if child.Sign() == 0 {
+			return nil, InvalidDerivationErr
+		}
+		key = &extendedKey{
+			key:               child,
+			chainCode:         chainCode,
+			depth:             key.depth + 1,
+			parentFingerprint: fingerprint(&parent.PublicKey),
+			childNumber:       index,
+		}
+	}
+	return key, nil
+}
+
+func (k *extendedKey) privateKey() (*ecdsa.PrivateKey, error) {
+	return crypto.ToECDSA(math.PaddedBigBytes(k.key, 32))
+}
+
+// neuter returns the extended public key of the extended private key
+func (k *extendedKey) neuter() (*ExtendedPublicKey, error) {
+	privateKey, err := k.privateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &ExtendedPublicKey{
+		version:           xpubVersion,
+		depth:             k.depth,
+		parentFingerprint: k.parentFingerprint,
+		childNumber:       k.childNumber,
+		chainCode:         k.chainCode,
+		key:               &privateKey.PublicKey,
+	}, nil
+}
+
+// childTweak computes HMAC-SHA512(chainCode, data || index), and splits it into the key tweak and the child chain code
+func childTweak(chainCode []byte, data []byte, index uint32) (tweak *big.Int, childChainCode []byte) {
+	var indexBytes [4]byte
+	binary.BigEndian.PutUint32(indexBytes[:], index)
+	mac := hmac.New(sha512.New, chainCode)
+	mac.Write(data)
+	mac.Write(indexBytes[:])
+	sum := mac.Sum(nil)
+	return new(big.Int).SetBytes(sum[:32]), sum[32:]
+}
@@ -0,0 +1,80 @@
+package sdk
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"testing"
+)
+
+func TestSDK_DeriveAccount(t *testing.T) {
+	sdk := &SDK{}
+	// the default mnemonic of ganache, from which the predefined accounts are derived
+	mnemonic := "myth like bonus scare over problem client lizard pioneer submit female collect"
+	for i, privateKey := range PredefinedPrivateKeys {
+		account, err := sdk.DeriveAccount(mnemonic, uint32(i))
+		checkError(t, err)
+		if account.PrivateKey() != privateKey || account.Address() != PredefinedAccounts[i].Address() {
+			t.Fatal("derived account", i, "is incorrect, got", account.Address())
+		}
+	}
+
+	_, err := sdk.DeriveAccount("myth like bonus scare over problem client lizard pioneer submit female female", 0)
+	if err != InvalidMnemonicErr {
+		t.Fatal("expect InvalidMnemonicErr, got", err)
+	}
+
+	mnemonic, err = NewMnemonic()
+	checkError(t, err)
+	first, err := sdk.DeriveAccount(mnemonic, 0)
+	checkError(t, err)
+	second, err := sdk.DeriveAccount(mnemonic, 1)
+	checkError(t, err)
+	if first.Address() == second.Address() {
+		t.Fatal("accounts of different indexes should be different")
+	}
+}
+
+func TestAccount_ExportKeystore(t *testing.T) {
+	KeystoreScryptN, KeystoreScryptP = keystore.LightScryptN, keystore.LightScryptP
+	defer func() {
+		KeystoreScryptN, KeystoreScryptP = keystore.StandardScryptN, keystore.StandardScryptP
+	}()
+	sdk := &SDK{}
+	account := sdk.CreateAccount()
+
+	keyJSON, err := account.ExportKeystore("passphrase")
+	checkError(t, err)
+	_, err = sdk.ImportAccount(keyJSON, "wrong")
+	if err != keystore.ErrDecrypt {
+		t.Fatal("expect ErrDecrypt, got", err)
+	}
+	imported, err := sdk.ImportAccount(keyJSON, "passphrase")
+	checkError(t, err)
+	if imported.Address() != account.Address() || imported.PrivateKey() != account.PrivateKey() {
+		t.Fatal("imported account is different from the exported one")
+	}
+
+	// an account backed by the keystore can be exported with another passphrase
+	signer, err := NewKeystoreSigner(keyJSON, "passphrase")
+	checkError(t, err)
+	keyJSON, err = NewAccount(signer).ExportKeystore("another")
+	checkError(t, err)
+	imported, err = sdk.ImportAccount(keyJSON, "another")
+	checkError(t, err)
+	if imported.Address() != account.Address() {
+		t.Fatal("re-exported account is different")
+	}
+
+	// the private key is 0x-prefixed hex
+	retrieved, err := sdk.RetrieveAccount(account.PrivateKey())
+	checkError(t, err)
+	if retrieved.Address() != account.Address() {
+		t.Fatal("private key is not hex")
+	}
+
+	mock := NewMockRemoteSigner(account.Signer())
+	defer mock.Stop()
+	_, err = NewAccount(mock.Signer(account.Address())).ExportKeystore("passphrase")
+	if err != PrivateKeyUnavailableErr {
+		t.Fatal("expect PrivateKeyUnavailableErr, got", err)
+	}
+}
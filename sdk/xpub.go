@@ -0,0 +1,168 @@
+package sdk
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/binary"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/ripemd160"
+	"math/big"
+)
+
+// version bytes of serialized extended public keys of mainnet (xpub) and testnet (tpub)
+var (
+	xpubVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+	tpubVersion = [4]byte{0x04, 0x35, 0x87, 0xcf}
+)
+
+/**
+ExtendedPublicKey is a BIP-32 extended public key (xpub),
+from which the public keys (and addresses) of non-hardened children are derived without any private key.
+*/
+type ExtendedPublicKey struct {
+	version           [4]byte
+	depth             byte
+	parentFingerprint [4]byte
+	childNumber       uint32
+	chainCode         []byte
+	key               *ecdsa.PublicKey
+}
+
+/**
+Parse the base58 serialized extended public key, e.g. "xpub6...".
+*/
+func ParseExtendedPublicKey(xpub string) (key *ExtendedPublicKey, err error) {
+	payload := base58Decode(xpub)
+	if len(payload) != 82 {
+		return nil, InvalidExtendedKeyErr
+	}
+	payload, checksum := payload[:78], payload[78:]
+	if !bytes.Equal(doubleSha256(payload)[:4], checksum) {
+		return nil, InvalidExtendedKeyErr
+	}
+	key = &ExtendedPublicKey{
+		depth:       payload[4],
+		childNumber: binary.BigEndian.Uint32(payload[9:13]),
+		chainCode:   payload[13:45],
+	}
+	copy(key.version[:], payload[:4])
+	copy(key.parentFingerprint[:], payload[5:9])
+	if key.version != xpubVersion && key.version != tpubVersion {
+		return nil, InvalidExtendedKeyErr
+	}
+	key.key, err = crypto.DecompressPubkey(payload[45:])
+	if err != nil {
+		return nil, InvalidExtendedKeyErr
+	}
+	return key, nil
+}
+
+/**
+Returns the base58 serialization of the extended public key.
+*/
+func (k *ExtendedPublicKey) String() string {
+	payload := make([]byte, 0, 82)
+	payload = append(payload, k.version[:]...)
+	payload = append(payload, k.depth)
+	payload = append(payload, k.parentFingerprint[:]...)
+	var childNumber [4]byte
+	binary.BigEndian.PutUint32(childNumber[:], k.childNumber)
+	payload = append(payload, childNumber[:]...)
+	payload = append(payload, k.chainCode...)
+	payload = append(payload, crypto.CompressPubkey(k.key)...)
+	payload = append(payload, doubleSha256(payload)[:4]...)
+	return base58Encode(payload)
+}
+
+/**
+Derive the non-hardened child extended public key with index, following BIP-32.
+*/
+func (k *ExtendedPublicKey) Child(index uint32) (child *ExtendedPublicKey, err error) {
+	if index >= 0x80000000 {
+		// hardened children can only be derived from private keys
+		return nil, InvalidDerivationErr
+	}
+	curve := crypto.S256()
+	tweak, chainCode := childTweak(k.chainCode, crypto.CompressPubkey(k.key), index)
+	if tweak.Cmp(curve.Params().N) >= 0 {
+		return nil, InvalidDerivationErr
+	}
+	tx, ty := curve.ScalarBaseMult(tweak.Bytes())
+	x, y := curve.Add(tx, ty, k.key.X, k.key.Y)
+	if x.Sign() == 0 && y.Sign() == 0 {
+		return nil, InvalidDerivationErr
+	}
+	return &ExtendedPublicKey{
+		version:           k.version,
+		depth:             k.depth + 1,
+		parentFingerprint: fingerprint(k.key),
+		childNumber:       index,
+		chainCode:         chainCode,
+		key:               &ecdsa.PublicKey{Curve: curve, X: x, Y: y},
+	}, nil
+}
+
+/**
+Returns the address of the public key.
+*/
+func (k *ExtendedPublicKey) Address() Address {
+	return Address(crypto.PubkeyToAddress(*k.key).Hex())
+}
+
+// fingerprint is the first 4 bytes of hash160 of the compressed public key, identifying the parent of an extended key
+func fingerprint(key *ecdsa.PublicKey) (fp [4]byte) {
+	sha := sha256.Sum256(crypto.CompressPubkey(key))
+	hash := ripemd160.New()
+	hash.Write(sha[:])
+	copy(fp[:], hash.Sum(nil))
+	return fp
+}
+
+func doubleSha256(data []byte) []byte {
+	first := sha256.Sum256(data)
+	second := sha256.Sum256(first[:])
+	return second[:]
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Encode(data []byte) string {
+	x := new(big.Int).SetBytes(data)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+	var encoded []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	// leading zero bytes are encoded as leading '1'
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
+
+// base58Decode returns nil if s is not base58 encoded
+func base58Decode(s string) []byte {
+	x := new(big.Int)
+	radix := big.NewInt(58)
+	for _, c := range []byte(s) {
+		digit := bytes.IndexByte([]byte(base58Alphabet), c)
+		if digit < 0 {
+			return nil
+		}
+		x.Mul(x, radix).Add(x, big.NewInt(int64(digit)))
+	}
+	zeros := 0
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	return append(make([]byte, zeros), x.Bytes()...)
+}
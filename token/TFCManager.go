@@ -4,6 +4,7 @@
 package token
 
 import (
+	"errors"
 	"math/big"
 	"strings"
 
@@ -17,6 +18,7 @@ import (
 
 // Reference imports to suppress errors if they are not otherwise used.
 var (
+	_ = errors.New
 	_ = big.NewInt
 	_ = strings.NewReader
 	_ = ethereum.NotFound
@@ -26,20 +28,31 @@ var (
 	_ = event.NewSubscription
 )
 
+// TFCManagerMetaData contains all meta data concerning the TFCManager contract.
+var TFCManagerMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"sig\",\"type\":\"bytes\"}],\"name\":\"ClaimTFC\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"sig\",\"type\":\"bytes\"}],\"name\":\"claimTFC\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"signer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tfcToken\",\"outputs\":[{\"internalType\":\"contractTFCToken\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"usedNonces\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b5033600160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055503330604051610060906100ff565b808373ffffffffffffffffffffffffffffffffffffffff1681526020018273ffffffffffffffffffffffffffffffffffffffff16815260200192505050604051809103906000f0801580156100b9573d6000803e3d6000fd5b50600260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555061010c565b612ec88061088483390190565b6107698061011b6000396000f3fe608060405234801561001057600080fd5b506004361061004c5760003560e01c8063238ac933146100515780632d6fec1d146100855780636717e41c14610154578063ff59d11814610198575b600080fd5b6100596101cc565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b6101526004803603606081101561009b57600080fd5b810190808035906020019092919080359060200190929190803590602001906401000000008111156100cc57600080fd5b8201836020820111156100de57600080fd5b8035906020019184600183028401116401000000008311171561010057600080fd5b91908080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f8201169050808301925050505050505091929192905050506101f2565b005b6101806004803603602081101561016a57600080fd5b810190808035906020019092919050505061055f565b60405180821515815260200191505060405180910390f35b6101a061057f565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b600160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60008083815260200190815260200160002060009054906101000a900460ff1615610285576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601b8152602001807f6e6f6e63652068617320616c7265616479206265656e2075736564000000000081525060200191505060405180910390fd5b600160008084815260200190815260200160002060006101000a81548160ff021916908315150217905550600061032b33858530604051602001808573ffffffffffffffffffffffffffffffffffffffff1660601b81526014018481526020018381526020018273ffffffffffffffffffffffffffffffffffffffff1660601b8152601401945050505050604051602081830303815290604052805190602001206105a5565b9050600160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1661037082846105fd565b73ffffffffffffffffffffffffffffffffffffffff16146103f9576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260128152602001807f756e617574686f72697a656420636c61696d000000000000000000000000000081525060200191505060405180910390fd5b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166340c10f1933866040518363ffffffff1660e01b8152600401808373ffffffffffffffffffffffffffffffffffffffff16815260200182815260200192505050600060405180830381600087803b15801561048c57600080fd5b505af11580156104a0573d6000803e3d6000fd5b5050505082843373ffffffffffffffffffffffffffffffffffffffff167f0fb8fa078a2a089173a846a2b5210ce31ff98eae182881ba221e6c19fefd1333856040518080602001828103825283818151815260200191508051906020019080838360005b8381101561051f578082015181840152602081019050610504565b50505050905090810190601f16801561054c5780820380516001836020036101000a031916815260200191505b509250505060405180910390a450505050565b60006020528060005260406000206000915054906101000a900460ff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60008160405160200180807f19457468657265756d205369676e6564204d6573736167653a0a333200000000815250601c01828152602001915050604051602081830303815290604052805190602001209050919050565b60008060008061060c85610687565b80935081945082955050505060018684848460405160008152602001604052604051808581526020018460ff1681526020018381526020018281526020019450505050506020604051602081039080840390855afa158015610672573d6000803e3d6000fd5b50505060206040510351935050505092915050565b60008060006041845114610703576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601a8152602001807f7369676e6174757265206c656e67746820696e636f727265637400000000000081525060200191505060405180910390fd5b60008060006020870151925060408701519150606087015160001a9050808383955095509550505050919390925056fea2646970667358221220b42993ce4f88c7a1ff092ca18c676c92577caa5bd365f986eb42a8e89edf7d6a64736f6c634300060c003360806040523480156200001157600080fd5b5060405162002ec838038062002ec8833981810160405260408110156200003757600080fd5b8101908080519060200190929190805190602001909291905050506040518060400160405280600881526020017f544643546f6b656e0000000000000000000000000000000000000000000000008152506040518060400160405280600381526020017f54464300000000000000000000000000000000000000000000000000000000008152508382828160049080519060200190620000d9929190620003aa565b508060059080519060200190620000f2929190620003aa565b506012600660006101000a81548160ff021916908360ff16021790555050506000600660016101000a81548160ff021916908315150217905550620001416000801b826200021460201b60201c565b620001737f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6826200021460201b60201c565b620001a57f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a826200021460201b60201c565b620001d77f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a848826200021460201b60201c565b5050506200020c7f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6826200021460201b60201c565b505062000450565b6200022682826200022a60201b60201c565b5050565b6200025881600080858152602001908152602001600020600001620002cd60201b6200169d1790919060201c565b15620002c9576200026e6200030560201b60201c565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b6000620002fd836000018373ffffffffffffffffffffffffffffffffffffffff1660001b6200030d60201b60201c565b905092915050565b600033905090565b60006200032183836200038760201b60201c565b6200037c57826000018290806001815401808255809150506001900390600052602060002001600090919091909150558260000180549050836001016000848152602001908152602001600020819055506001905062000381565b600090505b92915050565b600080836001016000848152602001908152602001600020541415905092915050565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f10620003ed57805160ff19168380011785556200041e565b828001600101855582156200041e579182015b828111156200041d57825182559160200191906001019062000400565b5b5090506200042d919062000431565b5090565b5b808211156200044c57600081600090555060010162000432565b5090565b612a6880620004606000396000f3fe608060405234801561001057600080fd5b50600436106101cf5760003560e01c80635c975abb11610104578063a217fddf116100a2578063d539139311610071578063d539139314610a07578063d547741f14610a25578063dd62ed3e14610a73578063e63ab1e914610aeb576101cf565b8063a217fddf146108df578063a457c2d7146108fd578063a9059cbb14610961578063ca15c873146109c5576101cf565b80638456cb59116100de5780638456cb591461078c5780639010d07c1461079657806391d14854146107f857806395d89b411461085c576101cf565b80635c975abb146106c657806370a08231146106e657806379cc67901461073e576101cf565b8063313ce567116101715780633f4ba83a1161014b5780633f4ba83a146104de57806340c10f19146104e857806342966c6814610536578063436ff61b14610564576101cf565b8063313ce5671461040b57806336568abe1461042c578063395093511461047a576101cf565b806323b872dd116101ad57806323b872dd146102d9578063248a9ca31461035d578063282c51f31461039f5780632f2ff15d146103bd576101cf565b806306fdde03146101d4578063095ea7b31461025757806318160ddd146102bb575b600080fd5b6101dc610b09565b6040518080602001828103825283818151815260200191508051906020019080838360005b8381101561021c578082015181840152602081019050610201565b50505050905090810190601f1680156102495780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b6102a36004803603604081101561026d57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610bab565b60405180821515815260200191505060405180910390f35b6102c3610bc9565b6040518082815260200191505060405180910390f35b610345600480360360608110156102ef57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610bd3565b60405180821515815260200191505060405180910390f35b6103896004803603602081101561037357600080fd5b8101908080359060200190929190505050610cac565b6040518082815260200191505060405180910390f35b6103a7610ccb565b6040518082815260200191505060405180910390f35b610409600480360360408110156103d357600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610cef565b005b610413610d78565b604051808260ff16815260200191505060405180910390f35b6104786004803603604081101561044257600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610d8f565b005b6104c66004803603604081101561049057600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610e28565b60405180821515815260200191505060405180910390f35b6104e6610edb565b005b610534600480360360408110156104fe57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610f6b565b005b6105626004803603602081101561054c57600080fd5b8101908080359060200190929190505050610fff565b005b6106ae6004803603604081101561057a57600080fd5b810190808035906020019064010000000081111561059757600080fd5b8201836020820111156105a957600080fd5b803590602001918460208302840111640100000000831117156105cb57600080fd5b919080806020026020016040519081016040528093929190818152602001838360200280828437600081840152601f19601f8201169050808301925050505050505091929192908035906020019064010000000081111561062b57600080fd5b82018360208201111561063d57600080fd5b8035906020019184602083028401116401000000008311171561065f57600080fd5b919080806020026020016040519081016040528093929190818152602001838360200280828437600081840152601f19601f820116905080830192505050505050509192919290505050611099565b60405180821515815260200191505060405180910390f35b6106ce611151565b60405180821515815260200191505060405180910390f35b610728600480360360208110156106fc57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611168565b6040518082815260200191505060405180910390f35b61078a6004803603604081101561075457600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803590602001909291905050506111b1565b005b610794611299565b005b6107cc600480360360408110156107ac57600080fd5b810190808035906020019092919080359060200190929190505050611329565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b6108446004803603604081101561080e57600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff16906020019092919050505061135a565b60405180821515815260200191505060405180910390f35b61086461138b565b6040518080602001828103825283818151815260200191508051906020019080838360005b838110156108a4578082015181840152602081019050610889565b50505050905090810190601f1680156108d15780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b6108e761142d565b6040518082815260200191505060405180910390f35b6109496004803603604081101561091357600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050611434565b60405180821515815260200191505060405180910390f35b6109ad6004803603604081101561097757600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050611501565b60405180821515815260200191505060405180910390f35b6109f1600480360360208110156109db57600080fd5b810190808035906020019092919050505061151f565b6040518082815260200191505060405180910390f35b610a0f611545565b6040518082815260200191505060405180910390f35b610a7160048036036040811015610a3b57600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611569565b005b610ad560048036036040811015610a8957600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803573ffffffffffffffffffffffffffffffffffffffff1690602001909291905050506115f2565b6040518082815260200191505060405180910390f35b610af3611679565b6040518082815260200191505060405180910390f35b606060048054600181600116156101000203166002900480601f016020809104026020016040519081016040528092919081815260200182805460018160011615610100020316600290048015610ba15780601f10610b7657610100808354040283529160200191610ba1565b820191906000526020600020905b815481529060010190602001808311610b8457829003601f168201915b5050505050905090565b6000610bbf610bb86116cd565b84846116d5565b6001905092915050565b6000600354905090565b6000610be08484846118cc565b610ca184610bec6116cd565b610c9c8560405180606001604052806028815260200161289060289139600260008b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000610c526116cd565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b6116d5565b600190509392505050565b6000806000838152602001908152602001600020600201549050919050565b7f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a84881565b610d1560008084815260200190815260200160002060020154610d106116cd565b61135a565b610d6a576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252602f815260200180612726602f913960400191505060405180910390fd5b610d748282611c51565b5050565b6000600660009054906101000a900460ff16905090565b610d976116cd565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614610e1a576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252602f8152602001806129da602f913960400191505060405180910390fd5b610e248282611ce4565b5050565b6000610ed1610e356116cd565b84610ecc8560026000610e466116cd565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008973ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611d7790919063ffffffff16565b6116d5565b6001905092915050565b610f0c7f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a610f076116cd565b61135a565b610f61576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252603981526020018061297c6039913960400191505060405180910390fd5b610f69611dff565b565b610f9c7f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6610f976116cd565b61135a565b610ff1576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260368152602001806129466036913960400191505060405180910390fd5b610ffb8282611ef2565b5050565b6110307f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a84861102b6116cd565b61135a565b611085576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252603681526020018061285a6036913960400191505060405180910390fd5b6110966110906116cd565b826120bb565b50565b600081518351146110f5576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260348152602001806127996034913960400191505060405180910390fd5b60005b83518110156111465761113961110c6116cd565b85838151811061111857fe5b602002602001015185848151811061112c57fe5b60200260200101516118cc565b80806001019150506110f8565b506001905092915050565b6000600660019054906101000a900460ff16905090565b6000600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b6111e27f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a8486111dd6116cd565b61135a565b611237576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252603681526020018061285a6036913960400191505060405180910390fd5b6000611276826040518060600160405280602481526020016128b860249139611267866112626116cd565b6115f2565b611b919092919063ffffffff16565b905061128a836112846116cd565b836116d5565b61129483836120bb565b505050565b6112ca7f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a6112c56116cd565b61135a565b61131f576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260378152602001806128236037913960400191505060405180910390fd5b611327612281565b565b60006113528260008086815260200190815260200160002060000161237590919063ffffffff16565b905092915050565b60006113838260008086815260200190815260200160002060000161238f90919063ffffffff16565b905092915050565b606060058054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156114235780601f106113f857610100808354040283529160200191611423565b820191906000526020600020905b81548152906001019060200180831161140657829003601f168201915b5050505050905090565b6000801b81565b60006114f76114416116cd565b846114f2856040518060600160405280602581526020016129b5602591396002600061146b6116cd565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008a73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b6116d5565b6001905092915050565b600061151561150e6116cd565b84846118cc565b6001905092915050565b600061153e6000808481526020019081526020016000206000016123bf565b9050919050565b7f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a681565b61158f6000808481526020019081526020016000206002015461158a6116cd565b61135a565b6115e4576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260308152602001806127f36030913960400191505060405180910390fd5b6115ee8282611ce4565b5050565b6000600260008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905092915050565b7f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a81565b60006116c5836000018373ffffffffffffffffffffffffffffffffffffffff1660001b6123d4565b905092915050565b600033905090565b600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff16141561175b576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260248152602001806129226024913960400191505060405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614156117e1576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260228152602001806127776022913960400191505060405180910390fd5b80600260008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925836040518082815260200191505060405180910390a3505050565b600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff161415611952576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260258152602001806128fd6025913960400191505060405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614156119d8576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260238152602001806127036023913960400191505060405180910390fd5b6119e3838383612444565b611a4f816040518060600160405280602681526020016127cd60269139600160008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550611ae481600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611d7790919063ffffffff16565b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a3505050565b6000838311158290611c3e576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825283818151815260200191508051906020019080838360005b83811015611c03578082015181840152602081019050611be8565b50505050905090810190601f168015611c305780820380516001836020036101000a031916815260200191505b509250505060405180910390fd5b5060008385039050809150509392505050565b611c788160008085815260200190815260200160002060000161169d90919063ffffffff16565b15611ce057611c856116cd565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b611d0b8160008085815260200190815260200160002060000161245490919063ffffffff16565b15611d7357611d186116cd565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16837ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b60405160405180910390a45b5050565b600080828401905083811015611df5576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601b8152602001807f536166654d6174683a206164646974696f6e206f766572666c6f77000000000081525060200191505060405180910390fd5b8091505092915050565b600660019054906101000a900460ff16611e81576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260148152602001807f5061757361626c653a206e6f742070617573656400000000000000000000000081525060200191505060405180910390fd5b6000600660016101000a81548160ff0219169083151502179055507f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa611ec56116cd565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390a1565b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161415611f95576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601f8152602001807f45524332303a206d696e7420746f20746865207a65726f20616464726573730081525060200191505060405180910390fd5b611fa160008383612444565b611fb681600354611d7790919063ffffffff16565b60038190555061200e81600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611d7790919063ffffffff16565b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff16600073ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a35050565b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161415612141576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260218152602001806128dc6021913960400191505060405180910390fd5b61214d82600083612444565b6121b98160405180606001604052806022815260200161275560229139600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055506122118160035461248490919063ffffffff16565b600381905550600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a35050565b600660019054906101000a900460ff1615612304576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260108152602001807f5061757361626c653a207061757365640000000000000000000000000000000081525060200191505060405180910390fd5b6001600660016101000a81548160ff0219169083151502179055507f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a2586123486116cd565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390a1565b600061238483600001836124ce565b60001c905092915050565b60006123b7836000018373ffffffffffffffffffffffffffffffffffffffff1660001b612551565b905092915050565b60006123cd82600001612574565b9050919050565b60006123e08383612551565b61243957826000018290806001815401808255809150506001900390600052602060002001600090919091909150558260000180549050836001016000848152602001908152602001600020819055506001905061243e565b600090505b92915050565b61244f838383612585565b505050565b600061247c836000018373ffffffffffffffffffffffffffffffffffffffff1660001b6125f3565b905092915050565b60006124c683836040518060400160405280601e81526020017f536166654d6174683a207375627472616374696f6e206f766572666c6f770000815250611b91565b905092915050565b60008183600001805490501161252f576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260228152602001806126e16022913960400191505060405180910390fd5b82600001828154811061253e57fe5b9060005260206000200154905092915050565b600080836001016000848152602001908152602001600020541415905092915050565b600081600001805490509050919050565b6125908383836126db565b612598611151565b156125ee576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252602a815260200180612a09602a913960400191505060405180910390fd5b505050565b600080836001016000848152602001908152602001600020549050600081146126cf576000600182039050600060018660000180549050039050600086600001828154811061263e57fe5b906000526020600020015490508087600001848154811061265b57fe5b906000526020600020018190555060018301876001016000838152602001908152602001600020819055508660000180548061269357fe5b600190038181906000526020600020016000905590558660010160008781526020019081526020016000206000905560019450505050506126d5565b60009150505b92915050565b50505056fe456e756d657261626c655365743a20696e646578206f7574206f6620626f756e647345524332303a207472616e7366657220746f20746865207a65726f2061646472657373416363657373436f6e74726f6c3a2073656e646572206d75737420626520616e2061646d696e20746f206772616e7445524332303a206275726e20616d6f756e7420657863656564732062616c616e636545524332303a20617070726f766520746f20746865207a65726f20616464726573736172726179206c656e677468206f6620726563697069656e747320616e6420616d6f756e7473206d75737420626520657175616c45524332303a207472616e7366657220616d6f756e7420657863656564732062616c616e6365416363657373436f6e74726f6c3a2073656e646572206d75737420626520616e2061646d696e20746f207265766f6b6545524332304d696e7465725061757365724275726e65723a206d75737420686176652070617573657220726f6c6520746f20706175736545524332304d696e7465725061757365724275726e65723a206d7573742068617665206275726e657220726f6c6520746f206275726e45524332303a207472616e7366657220616d6f756e74206578636565647320616c6c6f77616e636545524332303a206275726e20616d6f756e74206578636565647320616c6c6f77616e636545524332303a206275726e2066726f6d20746865207a65726f206164647265737345524332303a207472616e736665722066726f6d20746865207a65726f206164647265737345524332303a20617070726f76652066726f6d20746865207a65726f206164647265737345524332304d696e7465725061757365724275726e65723a206d7573742068617665206d696e74657220726f6c6520746f206d696e7445524332304d696e7465725061757365724275726e65723a206d75737420686176652070617573657220726f6c6520746f20756e706175736545524332303a2064656372656173656420616c6c6f77616e63652062656c6f77207a65726f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636520726f6c657320666f722073656c6645524332305061757361626c653a20746f6b656e207472616e73666572207768696c6520706175736564a2646970667358221220e17b8eaade8e1dbf7268d75d4273a63b6b6d1f7787e14e100c31343027529c0d64736f6c634300060c0033",
+}
+
 // TFCManagerABI is the input ABI used to generate the binding from.
-const TFCManagerABI = "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"recipient\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"indexed\":true,\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"sig\",\"type\":\"bytes\"}],\"name\":\"ClaimTFC\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"nonce\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"sig\",\"type\":\"bytes\"}],\"name\":\"claimTFC\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"signer\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tfcToken\",\"outputs\":[{\"internalType\":\"contractTFCToken\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"usedNonces\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]"
+// Deprecated: Use TFCManagerMetaData.ABI instead.
+var TFCManagerABI = TFCManagerMetaData.ABI
 
 // TFCManagerBin is the compiled bytecode used for deploying new contracts.
-var TFCManagerBin = "0x608060405234801561001057600080fd5b5033600160006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff1602179055503330604051610060906100ff565b808373ffffffffffffffffffffffffffffffffffffffff1681526020018273ffffffffffffffffffffffffffffffffffffffff16815260200192505050604051809103906000f0801580156100b9573d6000803e3d6000fd5b50600260006101000a81548173ffffffffffffffffffffffffffffffffffffffff021916908373ffffffffffffffffffffffffffffffffffffffff16021790555061010c565b612ec88061088483390190565b6107698061011b6000396000f3fe608060405234801561001057600080fd5b506004361061004c5760003560e01c8063238ac933146100515780632d6fec1d146100855780636717e41c14610154578063ff59d11814610198575b600080fd5b6100596101cc565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b6101526004803603606081101561009b57600080fd5b810190808035906020019092919080359060200190929190803590602001906401000000008111156100cc57600080fd5b8201836020820111156100de57600080fd5b8035906020019184600183028401116401000000008311171561010057600080fd5b91908080601f016020809104026020016040519081016040528093929190818152602001838380828437600081840152601f19601f8201169050808301925050505050505091929192905050506101f2565b005b6101806004803603602081101561016a57600080fd5b810190808035906020019092919050505061055f565b60405180821515815260200191505060405180910390f35b6101a061057f565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b600160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60008083815260200190815260200160002060009054906101000a900460ff1615610285576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601b8152602001807f6e6f6e63652068617320616c7265616479206265656e2075736564000000000081525060200191505060405180910390fd5b600160008084815260200190815260200160002060006101000a81548160ff021916908315150217905550600061032b33858530604051602001808573ffffffffffffffffffffffffffffffffffffffff1660601b81526014018481526020018381526020018273ffffffffffffffffffffffffffffffffffffffff1660601b8152601401945050505050604051602081830303815290604052805190602001206105a5565b9050600160009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1661037082846105fd565b73ffffffffffffffffffffffffffffffffffffffff16146103f9576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260128152602001807f756e617574686f72697a656420636c61696d000000000000000000000000000081525060200191505060405180910390fd5b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff166340c10f1933866040518363ffffffff1660e01b8152600401808373ffffffffffffffffffffffffffffffffffffffff16815260200182815260200192505050600060405180830381600087803b15801561048c57600080fd5b505af11580156104a0573d6000803e3d6000fd5b5050505082843373ffffffffffffffffffffffffffffffffffffffff167f0fb8fa078a2a089173a846a2b5210ce31ff98eae182881ba221e6c19fefd1333856040518080602001828103825283818151815260200191508051906020019080838360005b8381101561051f578082015181840152602081019050610504565b50505050905090810190601f16801561054c5780820380516001836020036101000a031916815260200191505b509250505060405180910390a450505050565b60006020528060005260406000206000915054906101000a900460ff1681565b600260009054906101000a900473ffffffffffffffffffffffffffffffffffffffff1681565b60008160405160200180807f19457468657265756d205369676e6564204d6573736167653a0a333200000000815250601c01828152602001915050604051602081830303815290604052805190602001209050919050565b60008060008061060c85610687565b80935081945082955050505060018684848460405160008152602001604052604051808581526020018460ff1681526020018381526020018281526020019450505050506020604051602081039080840390855afa158015610672573d6000803e3d6000fd5b50505060206040510351935050505092915050565b60008060006041845114610703576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601a8152602001807f7369676e6174757265206c656e67746820696e636f727265637400000000000081525060200191505060405180910390fd5b60008060006020870151925060408701519150606087015160001a9050808383955095509550505050919390925056fea2646970667358221220b42993ce4f88c7a1ff092ca18c676c92577caa5bd365f986eb42a8e89edf7d6a64736f6c634300060c003360806040523480156200001157600080fd5b5060405162002ec838038062002ec8833981810160405260408110156200003757600080fd5b8101908080519060200190929190805190602001909291905050506040518060400160405280600881526020017f544643546f6b656e0000000000000000000000000000000000000000000000008152506040518060400160405280600381526020017f54464300000000000000000000000000000000000000000000000000000000008152508382828160049080519060200190620000d9929190620003aa565b508060059080519060200190620000f2929190620003aa565b506012600660006101000a81548160ff021916908360ff16021790555050506000600660016101000a81548160ff021916908315150217905550620001416000801b826200021460201b60201c565b620001737f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6826200021460201b60201c565b620001a57f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a826200021460201b60201c565b620001d77f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a848826200021460201b60201c565b5050506200020c7f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6826200021460201b60201c565b505062000450565b6200022682826200022a60201b60201c565b5050565b6200025881600080858152602001908152602001600020600001620002cd60201b6200169d1790919060201c565b15620002c9576200026e6200030560201b60201c565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b6000620002fd836000018373ffffffffffffffffffffffffffffffffffffffff1660001b6200030d60201b60201c565b905092915050565b600033905090565b60006200032183836200038760201b60201c565b6200037c57826000018290806001815401808255809150506001900390600052602060002001600090919091909150558260000180549050836001016000848152602001908152602001600020819055506001905062000381565b600090505b92915050565b600080836001016000848152602001908152602001600020541415905092915050565b828054600181600116156101000203166002900490600052602060002090601f016020900481019282601f10620003ed57805160ff19168380011785556200041e565b828001600101855582156200041e579182015b828111156200041d57825182559160200191906001019062000400565b5b5090506200042d919062000431565b5090565b5b808211156200044c57600081600090555060010162000432565b5090565b612a6880620004606000396000f3fe608060405234801561001057600080fd5b50600436106101cf5760003560e01c80635c975abb11610104578063a217fddf116100a2578063d539139311610071578063d539139314610a07578063d547741f14610a25578063dd62ed3e14610a73578063e63ab1e914610aeb576101cf565b8063a217fddf146108df578063a457c2d7146108fd578063a9059cbb14610961578063ca15c873146109c5576101cf565b80638456cb59116100de5780638456cb591461078c5780639010d07c1461079657806391d14854146107f857806395d89b411461085c576101cf565b80635c975abb146106c657806370a08231146106e657806379cc67901461073e576101cf565b8063313ce567116101715780633f4ba83a1161014b5780633f4ba83a146104de57806340c10f19146104e857806342966c6814610536578063436ff61b14610564576101cf565b8063313ce5671461040b57806336568abe1461042c578063395093511461047a576101cf565b806323b872dd116101ad57806323b872dd146102d9578063248a9ca31461035d578063282c51f31461039f5780632f2ff15d146103bd576101cf565b806306fdde03146101d4578063095ea7b31461025757806318160ddd146102bb575b600080fd5b6101dc610b09565b6040518080602001828103825283818151815260200191508051906020019080838360005b8381101561021c578082015181840152602081019050610201565b50505050905090810190601f1680156102495780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b6102a36004803603604081101561026d57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610bab565b60405180821515815260200191505060405180910390f35b6102c3610bc9565b6040518082815260200191505060405180910390f35b610345600480360360608110156102ef57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610bd3565b60405180821515815260200191505060405180910390f35b6103896004803603602081101561037357600080fd5b8101908080359060200190929190505050610cac565b6040518082815260200191505060405180910390f35b6103a7610ccb565b6040518082815260200191505060405180910390f35b610409600480360360408110156103d357600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610cef565b005b610413610d78565b604051808260ff16815260200191505060405180910390f35b6104786004803603604081101561044257600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050610d8f565b005b6104c66004803603604081101561049057600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610e28565b60405180821515815260200191505060405180910390f35b6104e6610edb565b005b610534600480360360408110156104fe57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050610f6b565b005b6105626004803603602081101561054c57600080fd5b8101908080359060200190929190505050610fff565b005b6106ae6004803603604081101561057a57600080fd5b810190808035906020019064010000000081111561059757600080fd5b8201836020820111156105a957600080fd5b803590602001918460208302840111640100000000831117156105cb57600080fd5b919080806020026020016040519081016040528093929190818152602001838360200280828437600081840152601f19601f8201169050808301925050505050505091929192908035906020019064010000000081111561062b57600080fd5b82018360208201111561063d57600080fd5b8035906020019184602083028401116401000000008311171561065f57600080fd5b919080806020026020016040519081016040528093929190818152602001838360200280828437600081840152601f19601f820116905080830192505050505050509192919290505050611099565b60405180821515815260200191505060405180910390f35b6106ce611151565b60405180821515815260200191505060405180910390f35b610728600480360360208110156106fc57600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611168565b6040518082815260200191505060405180910390f35b61078a6004803603604081101561075457600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803590602001909291905050506111b1565b005b610794611299565b005b6107cc600480360360408110156107ac57600080fd5b810190808035906020019092919080359060200190929190505050611329565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390f35b6108446004803603604081101561080e57600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff16906020019092919050505061135a565b60405180821515815260200191505060405180910390f35b61086461138b565b6040518080602001828103825283818151815260200191508051906020019080838360005b838110156108a4578082015181840152602081019050610889565b50505050905090810190601f1680156108d15780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b6108e761142d565b6040518082815260200191505060405180910390f35b6109496004803603604081101561091357600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050611434565b60405180821515815260200191505060405180910390f35b6109ad6004803603604081101561097757600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff16906020019092919080359060200190929190505050611501565b60405180821515815260200191505060405180910390f35b6109f1600480360360208110156109db57600080fd5b810190808035906020019092919050505061151f565b6040518082815260200191505060405180910390f35b610a0f611545565b6040518082815260200191505060405180910390f35b610a7160048036036040811015610a3b57600080fd5b8101908080359060200190929190803573ffffffffffffffffffffffffffffffffffffffff169060200190929190505050611569565b005b610ad560048036036040811015610a8957600080fd5b81019080803573ffffffffffffffffffffffffffffffffffffffff169060200190929190803573ffffffffffffffffffffffffffffffffffffffff1690602001909291905050506115f2565b6040518082815260200191505060405180910390f35b610af3611679565b6040518082815260200191505060405180910390f35b606060048054600181600116156101000203166002900480601f016020809104026020016040519081016040528092919081815260200182805460018160011615610100020316600290048015610ba15780601f10610b7657610100808354040283529160200191610ba1565b820191906000526020600020905b815481529060010190602001808311610b8457829003601f168201915b5050505050905090565b6000610bbf610bb86116cd565b84846116d5565b6001905092915050565b6000600354905090565b6000610be08484846118cc565b610ca184610bec6116cd565b610c9c8560405180606001604052806028815260200161289060289139600260008b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000610c526116cd565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b6116d5565b600190509392505050565b6000806000838152602001908152602001600020600201549050919050565b7f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a84881565b610d1560008084815260200190815260200160002060020154610d106116cd565b61135a565b610d6a576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252602f815260200180612726602f913960400191505060405180910390fd5b610d748282611c51565b5050565b6000600660009054906101000a900460ff16905090565b610d976116cd565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff1614610e1a576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252602f8152602001806129da602f913960400191505060405180910390fd5b610e248282611ce4565b5050565b6000610ed1610e356116cd565b84610ecc8560026000610e466116cd565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008973ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611d7790919063ffffffff16565b6116d5565b6001905092915050565b610f0c7f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a610f076116cd565b61135a565b610f61576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252603981526020018061297c6039913960400191505060405180910390fd5b610f69611dff565b565b610f9c7f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a6610f976116cd565b61135a565b610ff1576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260368152602001806129466036913960400191505060405180910390fd5b610ffb8282611ef2565b5050565b6110307f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a84861102b6116cd565b61135a565b611085576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252603681526020018061285a6036913960400191505060405180910390fd5b6110966110906116cd565b826120bb565b50565b600081518351146110f5576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260348152602001806127996034913960400191505060405180910390fd5b60005b83518110156111465761113961110c6116cd565b85838151811061111857fe5b602002602001015185848151811061112c57fe5b60200260200101516118cc565b80806001019150506110f8565b506001905092915050565b6000600660019054906101000a900460ff16905090565b6000600160008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020549050919050565b6111e27f3c11d16cbaffd01df69ce1c404f6340ee057498f5f00246190ea54220576a8486111dd6116cd565b61135a565b611237576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252603681526020018061285a6036913960400191505060405180910390fd5b6000611276826040518060600160405280602481526020016128b860249139611267866112626116cd565b6115f2565b611b919092919063ffffffff16565b905061128a836112846116cd565b836116d5565b61129483836120bb565b505050565b6112ca7f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a6112c56116cd565b61135a565b61131f576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260378152602001806128236037913960400191505060405180910390fd5b611327612281565b565b60006113528260008086815260200190815260200160002060000161237590919063ffffffff16565b905092915050565b60006113838260008086815260200190815260200160002060000161238f90919063ffffffff16565b905092915050565b606060058054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156114235780601f106113f857610100808354040283529160200191611423565b820191906000526020600020905b81548152906001019060200180831161140657829003601f168201915b5050505050905090565b6000801b81565b60006114f76114416116cd565b846114f2856040518060600160405280602581526020016129b5602591396002600061146b6116cd565b73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008a73ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b6116d5565b6001905092915050565b600061151561150e6116cd565b84846118cc565b6001905092915050565b600061153e6000808481526020019081526020016000206000016123bf565b9050919050565b7f9f2df0fed2c77648de5860a4cc508cd0818c85b8b8a1ab4ceeef8d981c8956a681565b61158f6000808481526020019081526020016000206002015461158a6116cd565b61135a565b6115e4576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260308152602001806127f36030913960400191505060405180910390fd5b6115ee8282611ce4565b5050565b6000600260008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054905092915050565b7f65d7a28e3265b37a6474929f336521b332c1681b933f6cb9f3376673440d862a81565b60006116c5836000018373ffffffffffffffffffffffffffffffffffffffff1660001b6123d4565b905092915050565b600033905090565b600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff16141561175b576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260248152602001806129226024913960400191505060405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614156117e1576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260228152602001806127776022913960400191505060405180910390fd5b80600260008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925836040518082815260200191505060405180910390a3505050565b600073ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff161415611952576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260258152602001806128fd6025913960400191505060405180910390fd5b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff1614156119d8576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260238152602001806127036023913960400191505060405180910390fd5b6119e3838383612444565b611a4f816040518060600160405280602681526020016127cd60269139600160008773ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002081905550611ae481600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611d7790919063ffffffff16565b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff168373ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a3505050565b6000838311158290611c3e576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825283818151815260200191508051906020019080838360005b83811015611c03578082015181840152602081019050611be8565b50505050905090810190601f168015611c305780820380516001836020036101000a031916815260200191505b509250505060405180910390fd5b5060008385039050809150509392505050565b611c788160008085815260200190815260200160002060000161169d90919063ffffffff16565b15611ce057611c856116cd565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b611d0b8160008085815260200190815260200160002060000161245490919063ffffffff16565b15611d7357611d186116cd565b73ffffffffffffffffffffffffffffffffffffffff168173ffffffffffffffffffffffffffffffffffffffff16837ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b60405160405180910390a45b5050565b600080828401905083811015611df5576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601b8152602001807f536166654d6174683a206164646974696f6e206f766572666c6f77000000000081525060200191505060405180910390fd5b8091505092915050565b600660019054906101000a900460ff16611e81576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260148152602001807f5061757361626c653a206e6f742070617573656400000000000000000000000081525060200191505060405180910390fd5b6000600660016101000a81548160ff0219169083151502179055507f5db9ee0a495bf2e6ff9c91a7834c1ba4fdd244a5e8aa4e537bd38aeae4b073aa611ec56116cd565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390a1565b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161415611f95576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252601f8152602001807f45524332303a206d696e7420746f20746865207a65726f20616464726573730081525060200191505060405180910390fd5b611fa160008383612444565b611fb681600354611d7790919063ffffffff16565b60038190555061200e81600160008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611d7790919063ffffffff16565b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508173ffffffffffffffffffffffffffffffffffffffff16600073ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a35050565b600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff161415612141576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260218152602001806128dc6021913960400191505060405180910390fd5b61214d82600083612444565b6121b98160405180606001604052806022815260200161275560229139600160008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054611b919092919063ffffffff16565b600160008473ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055506122118160035461248490919063ffffffff16565b600381905550600073ffffffffffffffffffffffffffffffffffffffff168273ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef836040518082815260200191505060405180910390a35050565b600660019054906101000a900460ff1615612304576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260108152602001807f5061757361626c653a207061757365640000000000000000000000000000000081525060200191505060405180910390fd5b6001600660016101000a81548160ff0219169083151502179055507f62e78cea01bee320cd4e420270b5ea74000d11b0c9f74754ebdbfc544b05a2586123486116cd565b604051808273ffffffffffffffffffffffffffffffffffffffff16815260200191505060405180910390a1565b600061238483600001836124ce565b60001c905092915050565b60006123b7836000018373ffffffffffffffffffffffffffffffffffffffff1660001b612551565b905092915050565b60006123cd82600001612574565b9050919050565b60006123e08383612551565b61243957826000018290806001815401808255809150506001900390600052602060002001600090919091909150558260000180549050836001016000848152602001908152602001600020819055506001905061243e565b600090505b92915050565b61244f838383612585565b505050565b600061247c836000018373ffffffffffffffffffffffffffffffffffffffff1660001b6125f3565b905092915050565b60006124c683836040518060400160405280601e81526020017f536166654d6174683a207375627472616374696f6e206f766572666c6f770000815250611b91565b905092915050565b60008183600001805490501161252f576040517f08c379a00000000000000000000000000000000000000000000000000000000081526004018080602001828103825260228152602001806126e16022913960400191505060405180910390fd5b82600001828154811061253e57fe5b9060005260206000200154905092915050565b600080836001016000848152602001908152602001600020541415905092915050565b600081600001805490509050919050565b6125908383836126db565b612598611151565b156125ee576040517f08c379a000000000000000000000000000000000000000000000000000000000815260040180806020018281038252602a815260200180612a09602a913960400191505060405180910390fd5b505050565b600080836001016000848152602001908152602001600020549050600081146126cf576000600182039050600060018660000180549050039050600086600001828154811061263e57fe5b906000526020600020015490508087600001848154811061265b57fe5b906000526020600020018190555060018301876001016000838152602001908152602001600020819055508660000180548061269357fe5b600190038181906000526020600020016000905590558660010160008781526020019081526020016000206000905560019450505050506126d5565b60009150505b92915050565b50505056fe456e756d657261626c655365743a20696e646578206f7574206f6620626f756e647345524332303a207472616e7366657220746f20746865207a65726f2061646472657373416363657373436f6e74726f6c3a2073656e646572206d75737420626520616e2061646d696e20746f206772616e7445524332303a206275726e20616d6f756e7420657863656564732062616c616e636545524332303a20617070726f766520746f20746865207a65726f20616464726573736172726179206c656e677468206f6620726563697069656e747320616e6420616d6f756e7473206d75737420626520657175616c45524332303a207472616e7366657220616d6f756e7420657863656564732062616c616e6365416363657373436f6e74726f6c3a2073656e646572206d75737420626520616e2061646d696e20746f207265766f6b6545524332304d696e7465725061757365724275726e65723a206d75737420686176652070617573657220726f6c6520746f20706175736545524332304d696e7465725061757365724275726e65723a206d7573742068617665206275726e657220726f6c6520746f206275726e45524332303a207472616e7366657220616d6f756e74206578636565647320616c6c6f77616e636545524332303a206275726e20616d6f756e74206578636565647320616c6c6f77616e636545524332303a206275726e2066726f6d20746865207a65726f206164647265737345524332303a207472616e736665722066726f6d20746865207a65726f206164647265737345524332303a20617070726f76652066726f6d20746865207a65726f206164647265737345524332304d696e7465725061757365724275726e65723a206d7573742068617665206d696e74657220726f6c6520746f206d696e7445524332304d696e7465725061757365724275726e65723a206d75737420686176652070617573657220726f6c6520746f20756e706175736545524332303a2064656372656173656420616c6c6f77616e63652062656c6f77207a65726f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636520726f6c657320666f722073656c6645524332305061757361626c653a20746f6b656e207472616e73666572207768696c6520706175736564a2646970667358221220e17b8eaade8e1dbf7268d75d4273a63b6b6d1f7787e14e100c31343027529c0d64736f6c634300060c0033"
+// Deprecated: Use TFCManagerMetaData.Bin instead.
+var TFCManagerBin = TFCManagerMetaData.Bin
 
 // DeployTFCManager deploys a new Ethereum contract, binding an instance of TFCManager to it.
 func DeployTFCManager(auth *bind.TransactOpts, backend bind.ContractBackend) (common.Address, *types.Transaction, *TFCManager, error) {
-	parsed, err := abi.JSON(strings.NewReader(TFCManagerABI))
+	parsed, err := TFCManagerMetaData.GetAbi()
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
+	if parsed == nil {
+		return common.Address{}, nil, nil, errors.New("GetABI returned nil")
+	}
 
-	address, tx, contract, err := bind.DeployContract(auth, parsed, common.FromHex(TFCManagerBin), backend)
+	address, tx, contract, err := bind.DeployContract(auth, *parsed, common.FromHex(TFCManagerBin), backend)
 	if err != nil {
 		return common.Address{}, nil, nil, err
 	}
@@ -154,7 +167,7 @@ func bindTFCManager(address common.Address, caller bind.ContractCaller, transact
 // sets the output to result. The result type might be a single field for simple
 // returns, a slice of interfaces for anonymous returns and a struct for named
 // returns.
-func (_TFCManager *TFCManagerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+func (_TFCManager *TFCManagerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
 	return _TFCManager.Contract.TFCManagerCaller.contract.Call(opts, result, method, params...)
 }
 
@@ -173,7 +186,7 @@ func (_TFCManager *TFCManagerRaw) Transact(opts *bind.TransactOpts, method strin
 // sets the output to result. The result type might be a single field for simple
 // returns, a slice of interfaces for anonymous returns and a struct for named
 // returns.
-func (_TFCManager *TFCManagerCallerRaw) Call(opts *bind.CallOpts, result interface{}, method string, params ...interface{}) error {
+func (_TFCManager *TFCManagerCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
 	return _TFCManager.Contract.contract.Call(opts, result, method, params...)
 }
 
@@ -192,12 +205,17 @@ func (_TFCManager *TFCManagerTransactorRaw) Transact(opts *bind.TransactOpts, me
 //
 // Solidity: function signer() view returns(address)
 func (_TFCManager *TFCManagerCaller) Signer(opts *bind.CallOpts) (common.Address, error) {
-	var (
-		ret0 = new(common.Address)
-	)
-	out := ret0
-	err := _TFCManager.contract.Call(opts, out, "signer")
-	return *ret0, err
+	var out []interface{}
+	err := _TFCManager.contract.Call(opts, &out, "signer")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
 }
 
 // Signer is a free data retrieval call binding the contract method 0x238ac933.
@@ -218,12 +236,17 @@ func (_TFCManager *TFCManagerCallerSession) Signer() (common.Address, error) {
 //
 // Solidity: function tfcToken() view returns(address)
 func (_TFCManager *TFCManagerCaller) TfcToken(opts *bind.CallOpts) (common.Address, error) {
-	var (
-		ret0 = new(common.Address)
-	)
-	out := ret0
-	err := _TFCManager.contract.Call(opts, out, "tfcToken")
-	return *ret0, err
+	var out []interface{}
+	err := _TFCManager.contract.Call(opts, &out, "tfcToken")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
 }
 
 // TfcToken is a free data retrieval call binding the contract method 0xff59d118.
@@ -244,12 +267,17 @@ func (_TFCManager *TFCManagerCallerSession) TfcToken() (common.Address, error) {
 //
 // Solidity: function usedNonces(uint256 ) view returns(bool)
 func (_TFCManager *TFCManagerCaller) UsedNonces(opts *bind.CallOpts, arg0 *big.Int) (bool, error) {
-	var (
-		ret0 = new(bool)
-	)
-	out := ret0
-	err := _TFCManager.contract.Call(opts, out, "usedNonces", arg0)
-	return *ret0, err
+	var out []interface{}
+	err := _TFCManager.contract.Call(opts, &out, "usedNonces", arg0)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
 }
 
 // UsedNonces is a free data retrieval call binding the contract method 0x6717e41c.
@@ -446,5 +474,6 @@ func (_TFCManager *TFCManagerFilterer) ParseClaimTFC(log types.Log) (*TFCManager
 	if err := _TFCManager.contract.UnpackLog(event, "ClaimTFC", log); err != nil {
 		return nil, err
 	}
+	event.Raw = log
 	return event, nil
 }